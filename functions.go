@@ -2,7 +2,13 @@
 package astro
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
 	"math"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-validator/validator"
@@ -12,152 +18,4994 @@ const (
 	jsonTimeFormat   = "2006-01-02T15:04:05-07:00"
 	jsonTimeNilValue = "n/a"
 
-	// J2000Epoch is January 1, 2000, 12:00 TT
-	J2000Epoch julianTime = 2451545.0
+	// j2000 is J2000Epoch as a julianTime
+	j2000 julianTime = 2451545.0
+
+	// mjdOffset is the Julian Date at the start of the Modified Julian Date
+	// count, 17 November 1858, 00:00 UTC
+	mjdOffset = 2400000.5
+
+	// ttMinusTAI is the fixed difference between TT and TAI
+	ttMinusTAI = 32184 * time.Millisecond
+
+	// Solar elevations (in degrees) bounding the bands of twilight
+	civilTwilightElevation        = -6.0
+	nauticalTwilightElevation     = -12.0
+	astronomicalTwilightElevation = -18.0
+	goldenHourElevation           = 6.0
+	blueHourElevation             = -4.0
+
+	// Standard altitudes (in degrees) of the centre of a Body at rising and
+	// setting, allowing for refraction and, for the Sun and Moon, the size
+	// of their disks, for use with RiseTransitSet
+	StandardAltitudeStar = -0.5667
+	StandardAltitudeSun  = -0.8333
+	StandardAltitudeMoon = -0.8333
+
+	// solarSemidiameter is the mean apparent radius (in degrees) of the Sun
+	solarSemidiameter = 16.0 / 60
+
+	// earthRadius is the equatorial radius (in kilometers) of the Earth, and
+	// earthPolarRatio the ratio of its polar radius to it
+	earthRadius     = 6378.14
+	earthPolarRatio = 0.99664719
+
+	// moonRadius is the mean radius (in kilometers) of the Moon
+	moonRadius = 1737.4
+
+	// astronomicalUnit is the length (in kilometers) of the astronomical unit
+	astronomicalUnit = 149597870.7
+
+	// lightTime is the time (in days) taken by light to travel one
+	// astronomical unit
+	lightTime = 0.0057755183
+
+	// aberrationConstant is the constant of annual aberration (in degrees)
+	aberrationConstant = 20.49552 / 3600
+
+	// synodicMonth is the mean period (in days) of the Moon's phases
+	synodicMonth = 29.530588853
+
+	// solarAberration is the displacement (in degrees) of the Sun towards
+	// the west by the aberration of light
+	solarAberration = 0.0056916
+
+	// The north galactic pole and the galactic longitude of the north
+	// celestial pole (in degrees), in the equatorial frame of J2000
+	galacticPoleRightAscension     = 192.85948
+	galacticPoleDeclination        = 27.12825
+	celestialPoleGalacticLongitude = 122.93192
+)
+
+var (
+	// DUT1 is the difference UT1 - UTC, which is published weekly by the
+	// IERS and is kept within 0.9 seconds of zero by leap seconds. It may be
+	// set to improve the accuracy of calculations depending on the Earth's
+	// rotation. It applies to the whole process and is read without
+	// synchronisation, so it must be set before any calculations run
+	// concurrently.
+	DUT1 time.Duration
+
+	// leapSeconds is the history of TAI - UTC. Until 1972 UTC was adjusted
+	// by changing the length of its second, so the offset at a julianTime j
+	// is offset + (j - 2400000.5 - mjd) * rate seconds.
+	leapSeconds = []struct {
+		start  julianTime
+		offset float64
+		mjd    float64
+		rate   float64
+	}{
+		{2437300.5, 1.4228180, 37300, 0.001296},
+		{2437512.5, 1.3728180, 37300, 0.001296},
+		{2437665.5, 1.8458580, 37665, 0.0011232},
+		{2438334.5, 1.9458580, 37665, 0.0011232},
+		{2438395.5, 3.2401300, 38761, 0.001296},
+		{2438486.5, 3.3401300, 38761, 0.001296},
+		{2438639.5, 3.4401300, 38761, 0.001296},
+		{2438761.5, 3.5401300, 38761, 0.001296},
+		{2438820.5, 3.6401300, 38761, 0.001296},
+		{2438942.5, 3.7401300, 38761, 0.001296},
+		{2439004.5, 3.8401300, 38761, 0.001296},
+		{2439126.5, 4.3131700, 39126, 0.002592},
+		{2439887.5, 4.2131700, 39126, 0.002592},
+		{2441317.5, 10, 0, 0},
+		{2441499.5, 11, 0, 0},
+		{2441683.5, 12, 0, 0},
+		{2442048.5, 13, 0, 0},
+		{2442413.5, 14, 0, 0},
+		{2442778.5, 15, 0, 0},
+		{2443144.5, 16, 0, 0},
+		{2443509.5, 17, 0, 0},
+		{2443874.5, 18, 0, 0},
+		{2444239.5, 19, 0, 0},
+		{2444786.5, 20, 0, 0},
+		{2445151.5, 21, 0, 0},
+		{2445516.5, 22, 0, 0},
+		{2446247.5, 23, 0, 0},
+		{2447161.5, 24, 0, 0},
+		{2447892.5, 25, 0, 0},
+		{2448257.5, 26, 0, 0},
+		{2448804.5, 27, 0, 0},
+		{2449169.5, 28, 0, 0},
+		{2449534.5, 29, 0, 0},
+		{2450083.5, 30, 0, 0},
+		{2450630.5, 31, 0, 0},
+		{2451179.5, 32, 0, 0},
+		{2453736.5, 33, 0, 0},
+		{2454832.5, 34, 0, 0},
+		{2456109.5, 35, 0, 0},
+		{2457204.5, 36, 0, 0},
+		{2457754.5, 37, 0, 0},
+	}
+
+	// nutationTerms is the series for the IAU 1980 theory of nutation, as
+	// given by Meeus in Astronomical Algorithms. Each term has the multiples
+	// of the mean elongation of the Moon, the mean anomalies of the Sun and
+	// Moon, the Moon's argument of latitude and the longitude of its
+	// ascending node in its argument, then the coefficients (in units of
+	// 0.0001 arcseconds, and their rates per Julian century) of the sine of
+	// the argument in longitude and of its cosine in obliquity.
+	nutationTerms = []struct {
+		d, m, mp, f, o float64
+		psi, psiT      float64
+		eps, epsT      float64
+	}{
+		{0, 0, 0, 0, 1, -171996, -174.2, 92025, 8.9},
+		{-2, 0, 0, 2, 2, -13187, -1.6, 5736, -3.1},
+		{0, 0, 0, 2, 2, -2274, -0.2, 977, -0.5},
+		{0, 0, 0, 0, 2, 2062, 0.2, -895, 0.5},
+		{0, 1, 0, 0, 0, 1426, -3.4, 54, -0.1},
+		{0, 0, 1, 0, 0, 712, 0.1, -7, 0},
+		{-2, 1, 0, 2, 2, -517, 1.2, 224, -0.6},
+		{0, 0, 0, 2, 1, -386, -0.4, 200, 0},
+		{0, 0, 1, 2, 2, -301, 0, 129, -0.1},
+		{-2, -1, 0, 2, 2, 217, -0.5, -95, 0.3},
+		{-2, 0, 1, 0, 0, -158, 0, 0, 0},
+		{-2, 0, 0, 2, 1, 129, 0.1, -70, 0},
+		{0, 0, -1, 2, 2, 123, 0, -53, 0},
+		{2, 0, 0, 0, 0, 63, 0, 0, 0},
+		{0, 0, 1, 0, 1, 63, 0.1, -33, 0},
+		{2, 0, -1, 2, 2, -59, 0, 26, 0},
+		{0, 0, -1, 0, 1, -58, -0.1, 32, 0},
+		{0, 0, 1, 2, 1, -51, 0, 27, 0},
+		{-2, 0, 2, 0, 0, 48, 0, 0, 0},
+		{0, 0, -2, 2, 1, 46, 0, -24, 0},
+		{2, 0, 0, 2, 2, -38, 0, 16, 0},
+		{0, 0, 2, 2, 2, -31, 0, 13, 0},
+		{0, 0, 2, 0, 0, 29, 0, 0, 0},
+		{-2, 0, 1, 2, 2, 29, 0, -12, 0},
+		{0, 0, 0, 2, 0, 26, 0, 0, 0},
+		{-2, 0, 0, 2, 0, -22, 0, 0, 0},
+		{0, 0, -1, 2, 1, 21, 0, -10, 0},
+		{0, 2, 0, 0, 0, 17, -0.1, 0, 0},
+		{2, 0, -1, 0, 1, 16, 0, -8, 0},
+		{-2, 2, 0, 2, 2, -16, 0.1, 7, 0},
+		{0, 1, 0, 0, 1, -15, 0, 9, 0},
+		{-2, 0, 1, 0, 1, -13, 0, 7, 0},
+		{0, -1, 0, 0, 1, -12, 0, 6, 0},
+		{0, 0, 2, -2, 0, 11, 0, 0, 0},
+		{2, 0, -1, 2, 1, -10, 0, 5, 0},
+		{2, 0, 1, 2, 2, -8, 0, 3, 0},
+		{0, 1, 0, 2, 2, 7, 0, -3, 0},
+		{-2, 1, 1, 0, 0, -7, 0, 0, 0},
+		{0, -1, 0, 2, 2, -7, 0, 3, 0},
+		{2, 0, 0, 2, 1, -7, 0, 3, 0},
+		{2, 0, 1, 0, 0, 6, 0, 0, 0},
+		{-2, 0, 2, 2, 2, 6, 0, -3, 0},
+		{-2, 0, 1, 2, 1, 6, 0, -3, 0},
+		{2, 0, -2, 0, 1, -6, 0, 3, 0},
+		{2, 0, 0, 0, 1, -6, 0, 3, 0},
+		{0, -1, 1, 0, 0, 5, 0, 0, 0},
+		{-2, -1, 0, 2, 1, -5, 0, 3, 0},
+		{-2, 0, 0, 0, 1, -5, 0, 3, 0},
+		{0, 0, 2, 2, 1, -5, 0, 3, 0},
+		{-2, 0, 2, 0, 1, 4, 0, 0, 0},
+		{-2, 1, 0, 2, 1, 4, 0, 0, 0},
+		{0, 0, 1, -2, 0, 4, 0, 0, 0},
+		{-1, 0, 1, 0, 0, -4, 0, 0, 0},
+		{-2, 1, 0, 0, 0, -4, 0, 0, 0},
+		{1, 0, 0, 0, 0, -4, 0, 0, 0},
+		{0, 0, 1, 2, 0, 3, 0, 0, 0},
+		{0, 0, -2, 2, 2, -3, 0, 0, 0},
+		{-1, -1, 1, 0, 0, -3, 0, 0, 0},
+		{0, 1, 1, 0, 0, -3, 0, 0, 0},
+		{0, -1, 1, 2, 2, -3, 0, 0, 0},
+		{2, -1, -1, 2, 2, -3, 0, 0, 0},
+		{0, 0, 3, 2, 2, -3, 0, 0, 0},
+		{2, -1, 0, 2, 2, -3, 0, 0, 0},
+	}
+
+	// earthLongitudeSeries, earthLatitudeSeries and earthDistanceSeries give
+	// the heliocentric ecliptic longitude and latitude (in radians) of the
+	// Earth, referred to the mean equinox of date, and its distance (in
+	// astronomical units) from the Sun, abridged from the VSOP87 theory by
+	// Meeus in Astronomical Algorithms
+	earthLongitudeSeries = vsop87{
+		{
+			{175347046, 0, 0},
+			{3341656, 4.6692568, 6283.0758500},
+			{34894, 4.62610, 12566.15170},
+			{3497, 2.7441, 5753.3849},
+			{3418, 2.8289, 3.5231},
+			{3136, 3.6277, 77713.7715},
+			{2676, 4.4181, 7860.4194},
+			{2343, 6.1352, 3930.2097},
+			{1324, 0.7425, 11506.7698},
+			{1273, 2.0371, 529.6910},
+			{1199, 1.1096, 1577.3435},
+			{990, 5.233, 5884.927},
+			{902, 2.045, 26.298},
+			{857, 3.508, 398.149},
+			{780, 1.179, 5223.694},
+			{753, 2.533, 5507.553},
+			{505, 4.583, 18849.228},
+			{492, 4.205, 775.523},
+			{357, 2.920, 0.067},
+			{317, 5.849, 11790.629},
+			{284, 1.899, 796.298},
+			{271, 0.315, 10977.079},
+			{243, 0.345, 5486.778},
+			{206, 4.806, 2544.314},
+			{205, 1.869, 5573.143},
+			{202, 2.458, 6069.777},
+			{156, 0.833, 213.299},
+			{132, 3.411, 2942.463},
+			{126, 1.083, 20.775},
+			{115, 0.645, 0.980},
+			{103, 0.636, 4694.003},
+			{102, 0.976, 15720.839},
+			{102, 4.267, 7.114},
+			{99, 6.21, 2146.17},
+			{98, 0.68, 155.42},
+			{86, 5.98, 161000.69},
+			{85, 1.30, 6275.96},
+			{85, 3.67, 71430.70},
+			{80, 1.81, 17260.15},
+			{79, 3.04, 12036.46},
+			{75, 1.76, 5088.63},
+			{74, 3.50, 3154.69},
+			{74, 4.68, 801.82},
+			{70, 0.83, 9437.76},
+			{62, 3.98, 8827.39},
+			{61, 1.82, 7084.90},
+			{57, 2.78, 6286.60},
+			{56, 4.39, 14143.50},
+			{56, 3.47, 6279.55},
+			{52, 0.19, 12139.55},
+			{52, 1.33, 1748.02},
+			{51, 0.28, 5856.48},
+			{49, 0.49, 1194.45},
+			{41, 5.37, 8429.24},
+			{41, 2.40, 19651.05},
+			{39, 6.17, 10447.39},
+			{37, 6.04, 10213.29},
+			{37, 2.57, 1059.38},
+			{36, 1.71, 2352.87},
+			{36, 1.78, 6812.77},
+			{33, 0.59, 17789.85},
+			{30, 0.44, 83996.85},
+			{30, 2.74, 1349.87},
+			{25, 3.16, 4690.48},
+		},
+		{
+			{628331966747, 0, 0},
+			{206059, 2.678235, 6283.075850},
+			{4303, 2.6351, 12566.1517},
+			{425, 1.590, 3.523},
+			{119, 5.796, 26.298},
+			{109, 2.966, 1577.344},
+			{93, 2.59, 18849.23},
+			{72, 1.14, 529.69},
+			{68, 1.87, 398.15},
+			{67, 4.41, 5507.55},
+			{59, 2.89, 5223.69},
+			{56, 2.17, 155.42},
+			{45, 0.40, 796.30},
+			{36, 0.47, 775.52},
+			{29, 2.65, 7.11},
+			{21, 5.34, 0.98},
+			{19, 1.85, 5486.78},
+			{19, 4.97, 213.30},
+			{17, 2.99, 6275.96},
+			{16, 0.03, 2544.31},
+			{16, 1.43, 2146.17},
+			{15, 1.21, 10977.08},
+			{12, 2.83, 1748.02},
+			{12, 3.26, 5088.63},
+			{12, 5.27, 1194.45},
+			{12, 2.08, 4694.00},
+			{11, 0.77, 553.57},
+			{10, 1.30, 6286.60},
+			{10, 4.24, 1349.87},
+			{9, 2.70, 242.73},
+			{9, 5.64, 951.72},
+			{8, 5.30, 2352.87},
+			{6, 2.65, 9437.76},
+			{6, 4.67, 4690.48},
+		},
+		{
+			{52919, 0, 0},
+			{8720, 1.0721, 6283.0758},
+			{309, 0.867, 12566.152},
+			{27, 0.05, 3.52},
+			{16, 5.19, 26.30},
+			{16, 3.68, 155.42},
+			{10, 0.76, 18849.23},
+			{9, 2.06, 77713.77},
+			{7, 0.83, 775.52},
+			{5, 4.66, 1577.34},
+			{4, 1.03, 7.11},
+			{4, 3.44, 5573.14},
+			{3, 5.14, 796.30},
+			{3, 6.05, 5507.55},
+			{3, 1.19, 242.73},
+			{3, 6.12, 529.69},
+			{3, 0.31, 398.15},
+			{3, 2.28, 553.57},
+			{2, 4.38, 5223.69},
+			{2, 3.75, 0.98},
+		},
+		{
+			{289, 5.844, 6283.076},
+			{35, 0, 0},
+			{17, 5.49, 12566.15},
+			{3, 5.20, 155.42},
+			{1, 4.72, 3.52},
+			{1, 5.30, 18849.23},
+			{1, 5.97, 242.73},
+		},
+		{
+			{114, 3.142, 0},
+			{8, 4.13, 6283.08},
+			{1, 3.84, 12566.15},
+		},
+		{
+			{1, 3.14, 0},
+		},
+	}
+
+	earthLatitudeSeries = vsop87{
+		{
+			{280, 3.199, 84334.662},
+			{102, 5.422, 5507.553},
+			{80, 3.88, 5223.69},
+			{44, 3.70, 2352.87},
+			{32, 4.00, 1577.34},
+		},
+		{
+			{9, 3.90, 5507.55},
+			{6, 1.73, 5223.69},
+		},
+	}
+
+	earthDistanceSeries = vsop87{
+		{
+			{100013989, 0, 0},
+			{1670700, 3.0984635, 6283.0758500},
+			{13956, 3.05525, 12566.15170},
+			{3084, 5.1985, 77713.7715},
+			{1628, 1.1739, 5753.3849},
+			{1576, 2.8469, 7860.4194},
+			{925, 5.453, 11506.770},
+			{542, 4.564, 3930.210},
+			{472, 3.661, 5884.927},
+			{346, 0.964, 5507.553},
+			{329, 5.900, 5223.694},
+			{307, 0.299, 5573.143},
+			{243, 4.273, 11790.629},
+			{212, 5.847, 1577.344},
+			{186, 5.022, 10977.079},
+			{175, 3.012, 18849.228},
+			{110, 5.055, 5486.778},
+			{98, 0.89, 6069.78},
+			{86, 5.69, 15720.84},
+			{86, 1.27, 161000.69},
+			{65, 0.27, 17260.15},
+			{63, 0.92, 529.69},
+			{57, 2.01, 83996.85},
+			{56, 5.24, 71430.70},
+			{49, 3.25, 2544.31},
+			{47, 2.58, 775.52},
+			{45, 5.54, 9437.76},
+			{43, 6.01, 6275.96},
+			{39, 5.36, 4694.00},
+			{38, 2.39, 8827.39},
+			{37, 0.83, 19651.05},
+			{37, 4.90, 12139.55},
+			{36, 1.67, 12036.46},
+			{35, 1.84, 2942.46},
+			{33, 0.24, 7084.90},
+			{32, 0.18, 5088.63},
+			{32, 1.78, 398.15},
+			{28, 1.21, 6286.60},
+			{28, 1.90, 6279.55},
+			{26, 4.59, 10447.39},
+		},
+		{
+			{103019, 1.107490, 6283.075850},
+			{1721, 1.0644, 12566.1517},
+			{702, 3.142, 0},
+			{32, 1.02, 18849.23},
+			{31, 2.84, 5507.55},
+			{25, 1.32, 5223.69},
+			{18, 1.42, 1577.34},
+			{10, 5.91, 10977.08},
+			{9, 1.42, 6275.96},
+			{9, 0.27, 5486.78},
+		},
+		{
+			{4359, 5.7846, 6283.0758},
+			{124, 5.579, 12566.152},
+			{12, 3.14, 0},
+			{9, 3.63, 77713.77},
+			{6, 1.87, 5573.14},
+			{3, 5.47, 18849.23},
+		},
+		{
+			{145, 4.273, 6283.076},
+			{7, 3.92, 12566.15},
+		},
+		{
+			{4, 2.56, 6283.08},
+		},
+	}
+
+	// The series below give the heliocentric positions of the other planets in
+	// the same way as those of the Earth, also abridged by Meeus
+	mercuryLongitudeSeries = vsop87{
+		{
+			{440250710, 0, 0},
+			{40989415, 1.48302034, 26087.90314157},
+			{5046294, 4.4778549, 52175.8062831},
+			{855347, 1.165203, 78263.709425},
+			{165590, 4.119692, 104351.612566},
+			{34562, 0.77931, 130439.51571},
+			{7583, 3.7135, 156527.4188},
+			{3560, 1.5120, 1109.3786},
+			{1803, 4.1033, 5661.3320},
+			{1726, 0.3583, 182615.3220},
+			{1590, 2.9951, 25028.5212},
+			{1365, 4.5992, 27197.2817},
+			{1017, 0.8803, 31749.2352},
+			{714, 1.541, 24978.525},
+			{644, 5.303, 21535.950},
+			{451, 6.050, 51116.424},
+			{404, 3.282, 208703.225},
+			{352, 5.242, 20426.571},
+			{345, 2.792, 15874.618},
+			{343, 5.765, 955.600},
+			{339, 5.863, 25558.212},
+			{325, 1.337, 53285.185},
+			{273, 2.495, 529.691},
+			{264, 3.917, 57837.138},
+			{260, 0.987, 4551.953},
+			{239, 0.113, 1059.382},
+			{235, 0.267, 11322.664},
+			{217, 0.660, 13521.751},
+			{209, 2.092, 47623.853},
+			{183, 2.629, 27043.503},
+			{182, 2.434, 25661.305},
+			{176, 4.536, 51066.428},
+			{173, 2.452, 24498.830},
+			{142, 3.360, 37410.567},
+			{138, 0.291, 10213.286},
+			{125, 3.721, 39609.655},
+			{118, 2.781, 77204.327},
+			{106, 4.206, 19804.827},
+		},
+		{
+			{2608814706223, 0, 0},
+			{1126008, 6.2170397, 26087.9031416},
+			{303471, 3.055655, 52175.806283},
+			{80538, 6.10455, 78263.70942},
+			{21245, 2.83532, 104351.61257},
+			{5592, 5.8268, 130439.5157},
+			{1472, 2.5185, 156527.4188},
+			{388, 5.480, 182615.322},
+			{352, 3.052, 1109.379},
+			{103, 2.149, 24978.525},
+		},
+		{
+			{53050, 0, 0},
+			{16904, 4.69072, 26087.90314},
+			{7397, 1.3474, 52175.8063},
+			{3018, 4.4564, 78263.7094},
+			{1107, 1.2623, 104351.6126},
+			{378, 4.320, 130439.516},
+			{123, 1.069, 156527.419},
+		},
+		{
+			{188, 0.035, 52175.806},
+			{142, 3.125, 26087.903},
+			{97, 3.00, 78263.71},
+			{44, 6.02, 104351.61},
+			{35, 0, 0},
+		},
+		{
+			{114, 3.1416, 0},
+			{3, 2.03, 26087.90},
+		},
+		{
+			{1, 3.14, 0},
+		},
+	}
+
+	mercuryLatitudeSeries = vsop87{
+		{
+			{11737529, 1.98357499, 26087.90314157},
+			{2388077, 5.0373896, 52175.8062831},
+			{1222840, 3.1415927, 0},
+			{543252, 1.796444, 78263.709425},
+			{129779, 4.831325, 104351.612566},
+			{31867, 1.58088, 130439.51571},
+			{7963, 4.6097, 156527.4188},
+			{2014, 1.3532, 182615.3220},
+		},
+		{
+			{429151, 3.501698, 26087.903142},
+			{146234, 3.141593, 0},
+			{22675, 0.01515, 52175.80628},
+			{10895, 0.48540, 78263.70942},
+			{6353, 3.42944, 104351.61257},
+			{2496, 0.16051, 130439.51571},
+			{860, 3.185, 156527.419},
+		},
+		{
+			{11831, 4.79066, 26087.90314},
+			{1914, 0, 0},
+			{1045, 1.2122, 52175.8063},
+			{266, 4.434, 78263.709},
+			{170, 1.623, 104351.613},
+		},
+		{
+			{235, 0.354, 26087.903},
+			{161, 0, 0},
+			{19, 4.36, 52175.81},
+		},
+		{
+			{4, 1.75, 26087.90},
+			{1, 3.14, 0},
+		},
+	}
+
+	mercuryDistanceSeries = vsop87{
+		{
+			{39528272, 0, 0},
+			{7834132, 6.1923372, 26087.9031416},
+			{795526, 2.959897, 52175.806283},
+			{121282, 6.010642, 78263.709425},
+			{21922, 2.77820, 104351.61257},
+			{4354, 5.8289, 130439.5157},
+			{918, 2.597, 156527.419},
+			{290, 1.424, 25028.521},
+			{260, 3.028, 27197.282},
+			{202, 5.647, 182615.322},
+			{201, 5.592, 31749.235},
+			{142, 6.253, 24978.525},
+			{100, 3.734, 21535.950},
+		},
+		{
+			{217348, 4.656172, 26087.903142},
+			{44142, 1.42386, 52175.80628},
+			{10094, 4.47466, 78263.70942},
+			{2433, 1.2423, 104351.6126},
+			{1624, 0, 0},
+			{604, 4.293, 130439.516},
+			{153, 1.061, 156527.419},
+		},
+		{
+			{3118, 3.0823, 26087.9031},
+			{1245, 6.1518, 52175.8063},
+			{425, 2.926, 78263.709},
+			{136, 6.023, 104351.613},
+			{42, 2.75, 130439.52},
+		},
+		{
+			{33, 1.68, 26087.90},
+			{24, 4.63, 52175.81},
+			{12, 1.39, 78263.71},
+		},
+	}
+
+	venusLongitudeSeries = vsop87{
+		{
+			{317614667, 0, 0},
+			{1353968, 5.5931332, 10213.2855462},
+			{89892, 5.30650, 20426.57109},
+			{5477, 4.4163, 7860.4194},
+			{3456, 2.6996, 11790.6291},
+			{2372, 2.9938, 3930.2097},
+			{1664, 4.2502, 1577.3435},
+			{1438, 4.1575, 9683.5946},
+			{1317, 5.1867, 26.2983},
+			{1201, 6.1536, 30639.8566},
+			{769, 0.816, 9437.763},
+			{761, 1.950, 529.691},
+			{708, 1.065, 775.523},
+			{585, 3.998, 191.448},
+			{500, 4.123, 15720.839},
+			{429, 3.586, 19367.189},
+			{327, 5.677, 5507.553},
+			{326, 4.591, 10404.734},
+			{232, 3.163, 9153.904},
+			{180, 4.653, 1109.379},
+			{155, 5.570, 19651.048},
+			{128, 4.226, 20.775},
+			{128, 0.962, 5661.332},
+			{106, 1.537, 801.821},
+		},
+		{
+			{1021352943053, 0, 0},
+			{95708, 2.46424, 10213.28555},
+			{14445, 0.51625, 20426.57109},
+			{213, 1.795, 30639.857},
+			{174, 2.655, 26.298},
+			{152, 6.106, 1577.344},
+			{82, 5.70, 191.45},
+			{70, 2.68, 9437.76},
+			{52, 3.60, 775.52},
+			{38, 1.03, 529.69},
+			{30, 1.25, 5507.55},
+			{25, 6.11, 10404.73},
+		},
+		{
+			{54127, 0, 0},
+			{3891, 0.3451, 10213.2855},
+			{1338, 2.0201, 20426.5711},
+			{24, 2.05, 26.30},
+			{19, 3.54, 30639.86},
+			{10, 3.97, 775.52},
+			{7, 1.52, 1577.34},
+			{6, 1.00, 191.45},
+		},
+		{
+			{136, 4.804, 10213.286},
+			{78, 3.67, 20426.57},
+			{26, 0, 0},
+		},
+		{
+			{114, 3.1416, 0},
+			{3, 5.21, 20426.57},
+			{2, 2.51, 10213.29},
+		},
+		{
+			{1, 3.14, 0},
+		},
+	}
+
+	venusLatitudeSeries = vsop87{
+		{
+			{5923638, 0.2670278, 10213.2855462},
+			{40108, 1.14737, 20426.57109},
+			{32815, 3.14159, 0},
+			{1011, 1.0895, 30639.8566},
+			{149, 6.254, 18073.705},
+			{138, 0.860, 1577.344},
+			{130, 3.672, 9437.763},
+			{120, 3.705, 2352.866},
+			{108, 4.539, 22003.915},
+		},
+		{
+			{513348, 1.803643, 10213.285546},
+			{4380, 3.3862, 20426.5711},
+			{199, 0, 0},
+			{197, 2.530, 30639.857},
+		},
+		{
+			{22378, 3.38509, 10213.28555},
+			{282, 0, 0},
+			{173, 5.256, 20426.571},
+			{27, 3.87, 30639.86},
+		},
+		{
+			{647, 4.992, 10213.286},
+			{20, 3.14, 0},
+			{6, 0.77, 20426.57},
+			{3, 5.44, 30639.86},
+		},
+		{
+			{14, 0.32, 10213.29},
+		},
+	}
+
+	venusDistanceSeries = vsop87{
+		{
+			{72334821, 0, 0},
+			{489824, 4.021518, 10213.285546},
+			{1658, 4.9021, 20426.5711},
+			{1632, 2.8455, 7860.4194},
+			{1378, 1.1285, 11790.6291},
+			{498, 2.587, 9683.595},
+			{374, 1.423, 3930.210},
+			{264, 5.529, 9437.763},
+			{237, 2.551, 15720.839},
+			{222, 2.013, 19367.189},
+			{126, 2.728, 1577.344},
+			{119, 3.020, 10404.734},
+		},
+		{
+			{34551, 0.89199, 10213.28555},
+			{234, 1.772, 20426.571},
+			{234, 3.142, 0},
+		},
+		{
+			{1407, 5.0637, 10213.2855},
+			{16, 5.47, 20426.57},
+			{13, 0, 0},
+		},
+		{
+			{50, 3.22, 10213.29},
+		},
+		{
+			{1, 0.92, 10213.29},
+		},
+	}
+
+	marsLongitudeSeries = vsop87{
+		{
+			{620347712, 0, 0},
+			{18656368, 5.05037100, 3340.61242670},
+			{1108217, 5.4009984, 6681.2248534},
+			{91798, 5.75479, 10021.83728},
+			{27745, 5.97050, 3.52312},
+			{12316, 0.84956, 2810.92146},
+			{10610, 2.93959, 2281.23050},
+			{8927, 4.15698, 0.01725},
+			{8716, 6.11005, 13362.44971},
+			{7775, 3.33969, 5621.84292},
+			{6798, 0.36462, 398.14900},
+			{4161, 0.22815, 2942.46342},
+			{3575, 1.66187, 2544.31442},
+			{3075, 0.85697, 191.44827},
+			{2938, 6.07894, 0.06731},
+			{2628, 0.64806, 3337.08931},
+			{2580, 0.02996, 3344.13555},
+			{2389, 5.03896, 796.29801},
+			{1799, 0.65634, 529.69097},
+			{1546, 2.91580, 1751.53953},
+			{1528, 1.14979, 6151.53389},
+			{1286, 3.06796, 2146.16542},
+			{1264, 3.62275, 5092.15196},
+			{1025, 3.69334, 8962.45535},
+			{892, 0.183, 16703.062},
+			{859, 2.401, 2914.014},
+			{833, 4.495, 3340.630},
+			{833, 2.464, 3340.595},
+			{749, 3.822, 155.420},
+			{724, 0.675, 3738.761},
+			{713, 3.663, 1059.382},
+			{655, 0.489, 3127.313},
+			{636, 2.922, 8432.764},
+			{553, 4.475, 1748.016},
+			{550, 3.810, 0.980},
+			{472, 3.625, 1194.447},
+			{426, 0.554, 6283.076},
+			{415, 0.497, 213.299},
+			{312, 0.999, 6677.702},
+			{307, 0.381, 6684.748},
+			{302, 4.486, 3532.061},
+			{299, 2.783, 6254.627},
+			{293, 4.221, 20.775},
+			{284, 5.769, 3149.164},
+			{281, 5.882, 1349.867},
+			{274, 0.542, 3340.545},
+			{274, 0.134, 3340.680},
+			{239, 5.372, 4136.910},
+			{236, 5.755, 3333.499},
+			{231, 1.282, 3870.303},
+			{221, 3.505, 382.897},
+			{204, 2.821, 1221.849},
+			{193, 3.357, 3.590},
+			{189, 1.491, 9492.146},
+			{179, 1.006, 951.718},
+			{174, 2.414, 553.569},
+			{172, 0.439, 5486.778},
+			{160, 3.949, 4562.461},
+			{144, 1.419, 135.065},
+			{140, 3.326, 2700.715},
+			{138, 4.301, 7.114},
+			{131, 4.045, 12303.068},
+			{128, 2.208, 1592.596},
+			{128, 1.807, 5088.629},
+			{117, 3.128, 7903.073},
+			{113, 3.701, 1589.073},
+			{110, 1.052, 242.729},
+			{105, 0.785, 8827.390},
+			{100, 3.243, 11773.377},
+		},
+		{
+			{334085627474, 0, 0},
+			{1458227, 3.6042605, 3340.6124267},
+			{164901, 3.926313, 6681.224853},
+			{19963, 4.26594, 10021.83728},
+			{3452, 4.7321, 3.5231},
+			{2485, 4.6128, 13362.4497},
+			{842, 4.459, 2281.230},
+			{538, 5.016, 398.149},
+			{521, 4.994, 3344.136},
+			{433, 2.561, 191.448},
+			{430, 5.316, 155.420},
+			{382, 3.539, 796.298},
+			{314, 4.963, 16703.062},
+			{283, 3.160, 2544.314},
+			{206, 4.569, 2146.165},
+			{169, 1.329, 3337.089},
+			{158, 4.185, 1751.540},
+			{134, 2.233, 0.980},
+			{134, 5.974, 1748.016},
+			{118, 6.024, 6151.534},
+			{117, 2.213, 1059.382},
+			{114, 2.129, 1194.447},
+			{114, 5.428, 3738.761},
+			{91, 1.10, 1349.87},
+			{85, 3.91, 553.57},
+			{83, 5.30, 6684.75},
+			{81, 4.43, 529.69},
+			{80, 2.25, 8962.46},
+			{73, 2.50, 951.72},
+			{73, 5.84, 242.73},
+			{71, 3.86, 2914.01},
+			{68, 5.02, 382.90},
+			{65, 1.02, 3340.60},
+			{65, 3.05, 3340.63},
+		},
+		{
+			{58016, 2.04979, 3340.61243},
+			{54188, 0, 0},
+			{13908, 2.45742, 6681.22485},
+			{2465, 2.8000, 10021.8373},
+			{398, 3.141, 13362.450},
+			{222, 3.194, 3.523},
+			{121, 0.543, 155.420},
+			{62, 3.49, 16703.06},
+			{54, 3.54, 3344.14},
+			{34, 6.00, 2281.23},
+			{32, 4.14, 191.45},
+			{30, 2.00, 796.30},
+			{23, 4.33, 242.73},
+			{22, 3.45, 398.15},
+			{20, 5.42, 553.57},
+			{16, 0.66, 0.98},
+			{16, 6.11, 2146.17},
+			{16, 1.22, 1748.02},
+			{15, 6.10, 3185.19},
+			{14, 4.02, 951.72},
+		},
+		{
+			{1482, 0.4443, 3340.6124},
+			{662, 0.885, 6681.225},
+			{188, 1.288, 10021.837},
+			{41, 1.65, 13362.45},
+			{26, 0, 0},
+			{23, 2.05, 155.42},
+			{10, 1.58, 3.52},
+			{8, 2.00, 16703.06},
+			{5, 2.82, 242.73},
+			{4, 2.02, 3344.14},
+		},
+		{
+			{114, 3.1416, 0},
+			{29, 5.64, 6681.22},
+			{24, 5.14, 3340.61},
+			{11, 6.03, 10021.84},
+			{3, 0.13, 13362.45},
+		},
+	}
+
+	marsLatitudeSeries = vsop87{
+		{
+			{3197135, 3.7683204, 3340.6124267},
+			{298033, 4.106170, 6681.224853},
+			{289105, 0, 0},
+			{31366, 4.44651, 10021.83728},
+			{3484, 4.7881, 13362.4497},
+			{443, 5.026, 3344.136},
+			{443, 5.652, 3337.089},
+			{399, 5.131, 16703.062},
+			{293, 3.793, 2281.230},
+			{182, 6.136, 6151.534},
+			{163, 4.264, 529.691},
+			{160, 2.232, 1059.382},
+			{149, 2.165, 5621.843},
+			{143, 1.182, 3340.595},
+			{143, 3.213, 3340.630},
+			{139, 2.418, 8962.455},
+		},
+		{
+			{350069, 5.368478, 3340.612427},
+			{14116, 3.14159, 0},
+			{9671, 5.4788, 6681.2249},
+			{1472, 3.2021, 10021.8373},
+			{426, 3.408, 13362.450},
+			{102, 0.776, 3337.089},
+			{79, 3.72, 16703.06},
+			{33, 3.46, 5621.84},
+			{26, 2.48, 2281.23},
+		},
+		{
+			{16727, 0.60221, 3340.61243},
+			{4987, 3.1416, 0},
+			{302, 5.559, 6681.225},
+			{26, 1.90, 13362.45},
+			{21, 0.92, 10021.84},
+			{12, 2.24, 3340.60},
+			{8, 2.25, 16703.06},
+		},
+		{
+			{607, 1.981, 3340.612},
+			{43, 0, 0},
+			{14, 1.80, 6681.22},
+			{3, 3.45, 10021.84},
+		},
+		{
+			{13, 0, 0},
+			{11, 3.46, 3340.61},
+			{1, 0.50, 6681.22},
+		},
+	}
+
+	marsDistanceSeries = vsop87{
+		{
+			{153033488, 0, 0},
+			{14184953, 3.47971284, 3340.61242670},
+			{660776, 3.817834, 6681.224853},
+			{46179, 4.15595, 10021.83728},
+			{8110, 5.5596, 2810.9215},
+			{7485, 1.7724, 5621.8429},
+			{5523, 1.3644, 2281.2305},
+			{3825, 4.4941, 13362.4497},
+			{2484, 4.9255, 2942.4634},
+			{2307, 0.0908, 2544.3144},
+			{1999, 5.3606, 3337.0893},
+			{1960, 4.7425, 3344.1355},
+			{1167, 2.1126, 5092.1520},
+			{1103, 5.0091, 398.1490},
+			{992, 5.839, 6151.534},
+			{899, 4.408, 529.691},
+			{807, 2.102, 1059.382},
+			{798, 3.448, 796.298},
+			{741, 1.499, 2146.165},
+			{726, 1.245, 8432.764},
+			{692, 2.134, 8962.455},
+			{633, 0.894, 3340.595},
+			{633, 2.924, 3340.630},
+			{630, 1.287, 1751.540},
+			{574, 0.829, 2914.014},
+			{526, 5.383, 3738.761},
+			{473, 5.199, 3127.313},
+			{348, 4.832, 16703.062},
+			{284, 2.907, 3532.061},
+			{280, 5.257, 6283.076},
+			{276, 1.218, 6254.627},
+			{275, 2.908, 1748.016},
+			{270, 3.764, 5884.927},
+			{239, 2.037, 1194.447},
+		},
+		{
+			{1107433, 2.0325052, 3340.6124267},
+			{103176, 2.370718, 6681.224853},
+			{12877, 0, 0},
+			{10816, 2.70888, 10021.83728},
+			{1195, 3.0470, 13362.4497},
+			{439, 2.888, 2281.230},
+			{396, 3.423, 3344.136},
+			{183, 1.584, 2544.314},
+			{136, 3.385, 16703.062},
+			{128, 6.043, 3337.089},
+			{128, 0.630, 1059.382},
+			{127, 1.954, 796.298},
+			{118, 2.998, 2146.165},
+			{88, 3.42, 398.15},
+			{83, 3.86, 3738.76},
+			{76, 4.45, 6151.53},
+			{72, 2.76, 529.69},
+			{67, 2.55, 1751.54},
+			{66, 4.41, 1748.02},
+			{58, 0.54, 1194.45},
+			{54, 0.68, 8962.46},
+			{51, 3.73, 6684.75},
+			{49, 5.73, 3340.60},
+			{49, 1.48, 3340.63},
+			{48, 2.58, 3149.16},
+			{48, 2.29, 2914.01},
+			{39, 2.32, 4136.91},
+		},
+		{
+			{44242, 0.47931, 3340.61243},
+			{8138, 0.8700, 6681.2249},
+			{1275, 1.2259, 10021.8373},
+			{187, 1.573, 13362.450},
+			{52, 3.14, 0},
+			{41, 1.97, 3344.14},
+			{27, 1.92, 155.42},
+			{18, 4.43, 2281.23},
+			{18, 2.03, 16703.06},
+		},
+		{
+			{1113, 5.1499, 3340.6124},
+			{424, 5.613, 6681.225},
+			{100, 5.997, 10021.837},
+			{20, 0.08, 13362.45},
+			{5, 3.14, 0},
+			{3, 0.43, 16703.06},
+		},
+		{
+			{20, 3.58, 3340.61},
+			{16, 4.05, 6681.22},
+			{6, 4.46, 10021.84},
+			{2, 4.84, 13362.45},
+		},
+	}
+
+	jupiterLongitudeSeries = vsop87{
+		{
+			{59954691, 0, 0},
+			{9695899, 5.0619179, 529.6909651},
+			{573610, 1.444062, 7.113547},
+			{306389, 5.417347, 1059.381930},
+			{97178, 4.14265, 632.78374},
+			{72903, 3.64043, 522.57742},
+			{64264, 3.41145, 103.09277},
+			{39806, 2.29377, 419.48464},
+			{38858, 1.27232, 316.39187},
+			{27965, 1.78455, 536.80451},
+			{13590, 5.77481, 1589.07290},
+			{8769, 3.6300, 949.1756},
+			{8246, 3.5823, 206.1855},
+			{7368, 5.0810, 735.8765},
+			{6263, 0.0250, 213.2991},
+			{6114, 4.5132, 1162.4747},
+			{5305, 4.1863, 1052.2684},
+			{5305, 1.3067, 14.2271},
+			{4905, 1.3208, 110.2063},
+			{4647, 4.6996, 3.9322},
+			{3045, 4.3168, 426.5982},
+			{2610, 1.5667, 846.0828},
+			{2028, 1.0638, 3.1814},
+			{1921, 0.9717, 639.8973},
+			{1765, 2.1415, 1066.4955},
+			{1723, 3.8804, 1265.5675},
+			{1633, 3.5820, 515.4639},
+			{1432, 4.2968, 625.6702},
+			{973, 4.098, 95.979},
+			{884, 2.437, 412.371},
+			{733, 6.085, 838.969},
+			{731, 3.806, 1581.959},
+			{709, 1.293, 742.990},
+			{692, 6.134, 2118.764},
+			{614, 4.109, 1478.867},
+			{582, 4.540, 309.278},
+			{495, 3.756, 323.505},
+			{441, 2.958, 454.909},
+			{417, 1.036, 2.448},
+			{390, 4.897, 1692.166},
+			{376, 4.703, 1368.660},
+			{341, 5.715, 533.623},
+			{330, 4.740, 0.048},
+			{262, 1.877, 0.963},
+			{261, 0.820, 380.128},
+			{257, 3.724, 199.072},
+			{244, 5.220, 728.763},
+			{235, 1.227, 909.819},
+			{220, 1.651, 543.918},
+			{207, 1.855, 525.759},
+			{202, 1.807, 1375.774},
+			{197, 5.293, 1155.361},
+			{175, 3.730, 942.062},
+			{175, 3.226, 1898.351},
+			{175, 5.910, 956.289},
+			{158, 4.365, 860.310},
+		},
+		{
+			{52993480757, 0, 0},
+			{489741, 4.220667, 529.690965},
+			{228919, 6.026475, 7.113547},
+			{27655, 4.57266, 1059.38193},
+			{20721, 5.45939, 522.57742},
+			{12106, 0.16986, 536.80451},
+			{6068, 4.4242, 103.0928},
+			{5434, 3.9848, 419.4846},
+			{4238, 5.8901, 14.2271},
+			{2212, 5.2677, 206.1855},
+			{1746, 4.9267, 1589.0729},
+			{1296, 5.5513, 3.1814},
+			{1173, 5.8565, 1052.2684},
+			{1163, 0.5145, 3.9322},
+			{1099, 5.3070, 515.4639},
+			{1007, 0.4648, 735.8765},
+			{1004, 3.1504, 426.5982},
+			{848, 5.758, 110.206},
+			{827, 4.803, 213.299},
+			{816, 0.586, 1066.496},
+			{725, 5.518, 639.897},
+			{568, 5.989, 625.670},
+			{474, 4.132, 412.371},
+			{413, 5.737, 95.979},
+			{345, 4.242, 632.784},
+			{336, 3.732, 1162.475},
+			{234, 4.035, 949.176},
+			{234, 6.243, 309.278},
+			{199, 1.505, 838.969},
+			{195, 2.219, 323.505},
+			{187, 6.086, 742.990},
+			{184, 6.280, 543.918},
+			{171, 5.417, 199.072},
+			{131, 0.626, 728.763},
+			{115, 0.680, 846.083},
+			{115, 5.286, 2118.764},
+			{108, 4.493, 956.289},
+		},
+		{
+			{47234, 4.32148, 7.11355},
+			{38966, 0, 0},
+			{30629, 2.93021, 529.69097},
+			{3189, 1.0550, 522.5774},
+			{2729, 4.8455, 536.8045},
+			{2723, 3.4141, 1059.3819},
+			{1721, 4.1873, 14.2271},
+			{383, 5.768, 419.485},
+			{378, 0.760, 515.464},
+			{367, 6.055, 103.093},
+			{337, 3.786, 3.181},
+			{308, 0.694, 206.186},
+			{218, 3.814, 1589.073},
+			{199, 5.340, 1066.495},
+			{197, 2.484, 3.932},
+			{156, 1.406, 1052.268},
+			{146, 3.814, 639.897},
+			{142, 1.634, 426.598},
+			{130, 5.837, 412.371},
+			{117, 1.414, 625.670},
+			{97, 4.03, 110.21},
+			{91, 1.11, 95.98},
+			{87, 2.52, 632.78},
+			{79, 4.64, 543.92},
+			{72, 2.22, 735.88},
+			{58, 0.83, 199.07},
+			{57, 3.12, 213.30},
+			{49, 1.67, 309.28},
+			{40, 4.02, 21.34},
+			{40, 0.62, 323.51},
+			{36, 2.33, 728.76},
+		},
+		{
+			{6502, 2.5986, 7.1135},
+			{1357, 1.3464, 529.6910},
+			{471, 2.475, 14.227},
+			{417, 3.245, 536.805},
+			{353, 2.974, 522.577},
+			{155, 2.076, 1059.382},
+			{87, 2.51, 515.46},
+			{44, 0, 0},
+			{34, 3.83, 1066.50},
+			{28, 2.45, 206.19},
+			{24, 1.28, 412.37},
+			{23, 2.98, 543.92},
+			{20, 2.10, 639.90},
+			{20, 1.40, 419.48},
+			{19, 1.59, 103.09},
+			{17, 2.30, 21.34},
+			{17, 2.60, 1589.07},
+			{16, 3.15, 625.67},
+			{16, 3.36, 1052.27},
+			{13, 2.76, 95.98},
+			{13, 2.54, 199.07},
+			{13, 6.27, 426.60},
+		},
+		{
+			{669, 0.853, 7.114},
+			{114, 3.142, 0},
+			{100, 0.743, 14.227},
+			{50, 1.65, 536.80},
+			{44, 5.82, 529.69},
+			{32, 4.86, 522.58},
+			{15, 4.29, 515.46},
+			{9, 0.71, 1059.38},
+		},
+		{
+			{50, 5.26, 7.11},
+			{16, 5.25, 14.23},
+			{4, 0.01, 536.80},
+			{2, 1.10, 522.58},
+			{1, 3.14, 0},
+		},
+	}
+
+	jupiterLatitudeSeries = vsop87{
+		{
+			{2268616, 3.5585261, 529.6909651},
+			{110090, 0, 0},
+			{109972, 3.908093, 1059.381930},
+			{8101, 3.6051, 522.5774},
+			{6438, 0.3063, 536.8045},
+			{6044, 4.2588, 1589.0729},
+			{1107, 2.9853, 1162.4747},
+			{944, 1.675, 426.598},
+			{942, 2.936, 1052.268},
+			{894, 1.754, 7.114},
+			{836, 5.179, 103.093},
+			{767, 2.155, 632.784},
+			{684, 3.678, 213.299},
+			{629, 0.643, 1066.495},
+			{559, 0.014, 846.083},
+			{532, 2.703, 110.206},
+			{464, 1.173, 949.176},
+			{431, 2.608, 419.485},
+			{351, 4.611, 2118.764},
+			{132, 4.778, 742.990},
+			{123, 3.350, 1692.166},
+			{116, 1.387, 323.505},
+			{115, 5.049, 316.392},
+			{104, 3.701, 515.464},
+			{103, 2.319, 1478.867},
+			{102, 3.153, 1581.959},
+		},
+		{
+			{177352, 5.701665, 529.690965},
+			{3230, 5.7794, 1059.3819},
+			{3081, 5.4746, 522.5774},
+			{2212, 4.7348, 536.8045},
+			{1694, 3.1416, 0},
+			{346, 4.746, 1052.268},
+			{234, 5.189, 1066.495},
+			{196, 6.186, 7.114},
+			{150, 3.927, 1589.073},
+			{114, 3.439, 632.784},
+			{97, 2.91, 949.18},
+			{82, 5.08, 1162.47},
+			{77, 2.51, 103.09},
+			{77, 0.61, 419.48},
+			{74, 5.50, 515.46},
+			{61, 5.45, 213.30},
+			{50, 3.95, 735.88},
+			{46, 0.54, 110.21},
+			{45, 1.90, 846.08},
+			{37, 4.70, 543.92},
+			{36, 6.11, 316.39},
+			{32, 4.92, 1581.96},
+		},
+		{
+			{8094, 1.4632, 529.6910},
+			{813, 3.1416, 0},
+			{742, 0.957, 522.577},
+			{399, 2.899, 536.805},
+			{342, 1.447, 1059.382},
+			{74, 0.41, 1052.27},
+			{46, 3.48, 1066.50},
+			{30, 1.93, 1589.07},
+			{29, 0.99, 515.46},
+			{23, 4.27, 7.11},
+			{14, 2.92, 543.92},
+			{12, 5.22, 632.78},
+			{11, 4.88, 949.18},
+			{6, 6.21, 1045.15},
+		},
+		{
+			{252, 3.381, 529.691},
+			{122, 2.733, 522.577},
+			{49, 1.04, 536.81},
+			{11, 2.31, 1052.27},
+			{8, 2.77, 515.46},
+			{7, 4.25, 1059.38},
+			{6, 1.78, 1066.50},
+			{4, 1.13, 543.92},
+			{3, 3.14, 0},
+		},
+		{
+			{15, 4.53, 522.58},
+			{5, 4.47, 529.69},
+			{4, 5.44, 536.81},
+			{3, 0, 0},
+			{2, 4.52, 515.46},
+			{1, 4.20, 1052.27},
+		},
+		{
+			{1, 0.09, 522.58},
+		},
+	}
+
+	jupiterDistanceSeries = vsop87{
+		{
+			{520887429, 0, 0},
+			{25209327, 3.49108640, 529.69096509},
+			{610600, 3.841154, 1059.381930},
+			{282029, 2.574199, 632.783739},
+			{187647, 2.075904, 522.577418},
+			{86793, 0.71001, 419.48464},
+			{72063, 0.21466, 536.80451},
+			{65517, 5.97996, 316.39187},
+			{30135, 2.16132, 949.17561},
+			{29135, 1.67759, 103.09277},
+			{23947, 0.27458, 7.11355},
+			{23453, 3.54023, 735.87651},
+			{22284, 4.19363, 1589.07290},
+			{13033, 2.96043, 1162.47470},
+			{12749, 2.71550, 1052.26838},
+			{9703, 1.9067, 206.1855},
+			{9161, 4.4135, 213.2991},
+			{7895, 2.4791, 426.5982},
+			{7058, 2.1818, 1265.5675},
+			{6138, 6.2642, 846.0828},
+			{5477, 5.6573, 639.8973},
+			{4170, 2.0161, 515.4639},
+			{4137, 2.7222, 625.6702},
+			{3503, 0.5653, 1066.4955},
+			{2617, 2.0099, 1581.9593},
+			{2500, 4.5518, 838.9693},
+			{2128, 6.1275, 742.9901},
+			{1912, 0.8562, 412.3711},
+			{1611, 3.0887, 1368.6603},
+			{1479, 2.6803, 1478.8666},
+			{1231, 1.8904, 323.5054},
+			{1217, 1.8017, 110.2063},
+			{1015, 1.3867, 454.9094},
+			{999, 2.872, 309.278},
+			{961, 4.549, 2118.764},
+			{886, 4.148, 533.623},
+			{821, 1.593, 898.801},
+			{812, 5.941, 909.819},
+			{777, 3.677, 728.763},
+			{727, 3.988, 1155.361},
+			{655, 2.791, 1685.052},
+			{654, 3.382, 1692.166},
+			{621, 4.823, 956.289},
+			{615, 2.276, 942.062},
+			{562, 0.081, 543.918},
+			{542, 0.284, 525.759},
+		},
+		{
+			{1271802, 2.6493751, 529.6909651},
+			{61662, 3.00076, 1059.38193},
+			{53444, 3.89718, 522.57742},
+			{41390, 0, 0},
+			{31185, 4.88277, 536.80451},
+			{11847, 2.41330, 419.48464},
+			{9166, 4.7598, 7.1135},
+			{3404, 3.3469, 1589.0729},
+			{3203, 5.2108, 735.8765},
+			{3176, 2.7930, 103.0928},
+			{2806, 3.7422, 515.4639},
+			{2677, 4.3305, 1052.2684},
+			{2600, 3.6344, 206.1855},
+			{2412, 1.4695, 426.5982},
+			{2101, 3.9276, 639.8973},
+			{1646, 5.3095, 1066.4955},
+			{1641, 4.4163, 625.6702},
+			{1050, 3.1611, 213.2991},
+			{1025, 2.5543, 412.3711},
+			{806, 2.678, 632.784},
+			{741, 2.171, 1162.475},
+			{677, 6.250, 838.969},
+			{567, 4.577, 742.990},
+			{485, 2.469, 949.176},
+			{469, 4.710, 543.918},
+			{445, 0.403, 323.505},
+			{416, 5.368, 728.763},
+			{402, 4.605, 309.278},
+			{347, 4.681, 14.227},
+			{338, 3.168, 956.289},
+			{261, 5.343, 846.083},
+			{247, 3.923, 942.062},
+			{220, 4.842, 1368.660},
+			{203, 5.600, 1155.361},
+			{200, 4.439, 1045.155},
+			{197, 3.706, 2118.764},
+			{196, 3.759, 199.072},
+			{184, 4.265, 95.979},
+			{180, 4.402, 532.872},
+			{170, 4.846, 526.510},
+			{146, 6.130, 533.623},
+			{133, 1.322, 110.206},
+			{132, 4.512, 525.759},
+		},
+		{
+			{79645, 1.35866, 529.69097},
+			{8252, 5.7777, 522.5774},
+			{7030, 3.2748, 536.8045},
+			{5314, 1.8384, 1059.3819},
+			{1861, 2.9768, 7.1135},
+			{964, 5.480, 515.464},
+			{836, 4.199, 419.485},
+			{498, 3.142, 0},
+			{427, 2.228, 639.897},
+			{406, 3.783, 1066.496},
+			{377, 2.242, 1589.073},
+			{363, 5.368, 206.186},
+			{342, 6.099, 1052.268},
+			{339, 6.127, 625.670},
+			{333, 0.003, 426.598},
+			{280, 4.262, 412.371},
+			{257, 0.963, 632.784},
+			{230, 0.705, 735.877},
+			{201, 3.069, 543.918},
+			{200, 4.429, 103.093},
+			{139, 2.932, 14.227},
+			{114, 0.787, 728.763},
+			{95, 1.70, 838.97},
+			{86, 5.14, 323.51},
+			{83, 0.06, 309.28},
+			{80, 2.98, 742.99},
+			{75, 1.60, 956.29},
+			{70, 1.51, 213.30},
+			{67, 5.47, 199.07},
+			{62, 6.10, 1045.15},
+			{56, 0.96, 1162.47},
+			{52, 5.58, 942.06},
+			{50, 2.72, 532.87},
+			{45, 5.52, 508.35},
+			{44, 0.27, 526.51},
+			{40, 5.95, 95.98},
+		},
+		{
+			{3519, 6.0580, 529.6910},
+			{1073, 1.6732, 536.8045},
+			{916, 1.413, 522.577},
+			{342, 0.523, 1059.382},
+			{255, 1.196, 7.114},
+			{222, 0.952, 515.464},
+			{90, 3.14, 0},
+			{69, 2.27, 1066.50},
+			{58, 1.41, 543.92},
+			{58, 0.53, 639.90},
+			{51, 5.98, 412.37},
+			{47, 1.58, 625.67},
+			{43, 6.12, 419.48},
+			{37, 1.18, 14.23},
+			{34, 1.67, 1052.27},
+			{34, 0.85, 206.19},
+			{31, 1.04, 1589.07},
+			{30, 4.63, 426.60},
+			{21, 2.50, 728.76},
+		},
+		{
+			{129, 0.084, 536.805},
+			{113, 4.249, 529.691},
+			{83, 3.30, 522.58},
+			{38, 2.73, 515.46},
+			{27, 5.69, 7.11},
+			{18, 5.40, 1059.38},
+			{13, 6.02, 543.92},
+			{9, 0.77, 1066.50},
+			{8, 5.68, 14.23},
+			{7, 1.43, 412.37},
+			{6, 5.12, 639.90},
+			{5, 3.34, 625.67},
+		},
+		{
+			{11, 4.75, 536.80},
+			{4, 5.92, 522.58},
+			{2, 5.57, 515.46},
+			{2, 4.30, 543.92},
+			{2, 3.69, 7.11},
+			{2, 4.13, 1059.38},
+			{2, 5.49, 1066.50},
+		},
+	}
+
+	saturnLongitudeSeries = vsop87{
+		{
+			{87401354, 0, 0},
+			{11107660, 3.96205090, 213.29909544},
+			{1414151, 4.5858152, 7.1135470},
+			{398379, 0.521120, 206.185548},
+			{350769, 3.303299, 426.598191},
+			{206816, 0.246584, 103.092774},
+			{79271, 3.84007, 220.41264},
+			{23990, 4.66977, 110.20632},
+			{16574, 0.43719, 419.48464},
+			{15820, 0.93809, 632.78374},
+			{15054, 2.71670, 639.89729},
+			{14907, 5.76903, 316.39187},
+			{14610, 1.56519, 3.93215},
+			{13160, 4.44891, 14.22709},
+			{13005, 5.98119, 11.04570},
+			{10725, 3.12940, 202.25340},
+			{6126, 1.7633, 277.0350},
+			{5863, 0.2366, 529.6910},
+			{5228, 4.2078, 3.1814},
+			{5020, 3.1779, 433.7117},
+			{4593, 0.6198, 199.0720},
+			{4006, 2.2448, 63.7359},
+			{3874, 3.2228, 138.5175},
+			{3269, 0.7749, 949.1756},
+			{2954, 0.9828, 95.9792},
+		},
+		{
+			{21354295596, 0, 0},
+			{1296855, 1.8282054, 213.2990954},
+			{564348, 2.885001, 7.113547},
+			{107679, 2.277699, 206.185548},
+			{98323, 1.08070, 426.59819},
+			{40255, 2.04128, 220.41264},
+			{19942, 1.27955, 103.09277},
+			{10512, 2.74880, 14.22709},
+			{6939, 0.4049, 639.8973},
+			{4803, 2.4419, 419.4846},
+			{4056, 2.9217, 110.2063},
+			{3769, 3.6497, 3.9322},
+			{3385, 2.4169, 3.1814},
+			{3302, 1.2626, 433.7117},
+			{3071, 2.3274, 199.0720},
+			{1953, 3.5639, 11.0457},
+			{1249, 2.6280, 95.9792},
+			{922, 1.961, 227.526},
+			{706, 4.417, 529.691},
+			{650, 6.174, 202.253},
+			{628, 6.111, 309.278},
+			{487, 6.040, 853.196},
+			{479, 4.988, 522.577},
+			{468, 4.617, 63.736},
+			{417, 2.117, 323.505},
+			{408, 1.299, 209.367},
+			{352, 2.317, 632.784},
+			{344, 3.959, 412.371},
+			{340, 3.634, 316.392},
+			{336, 3.772, 735.877},
+			{332, 2.861, 210.118},
+			{289, 2.733, 117.320},
+			{281, 5.744, 2.448},
+			{266, 0.543, 647.011},
+			{230, 1.644, 216.480},
+			{192, 2.965, 224.345},
+			{173, 4.077, 846.083},
+			{167, 2.597, 21.341},
+			{136, 2.286, 10.295},
+			{131, 3.441, 742.990},
+			{128, 4.095, 217.231},
+			{109, 6.161, 415.552},
+		},
+		{
+			{116441, 1.179882, 7.113547},
+			{91921, 0.07425, 213.29910},
+			{90592, 0, 0},
+			{15277, 4.06492, 206.18555},
+			{10631, 0.25778, 220.41264},
+			{10605, 5.40964, 426.59819},
+			{4265, 1.0460, 14.2271},
+			{1216, 2.9186, 103.0928},
+			{1165, 4.6094, 639.8973},
+			{1082, 5.6913, 433.7117},
+			{1045, 4.0421, 199.0720},
+			{1020, 0.6337, 3.1814},
+			{634, 4.388, 419.485},
+			{549, 5.573, 3.932},
+			{457, 1.268, 110.206},
+			{425, 0.209, 227.526},
+			{274, 4.288, 95.979},
+			{162, 1.381, 11.046},
+			{129, 1.566, 309.278},
+			{117, 3.881, 853.196},
+			{105, 4.900, 647.011},
+			{101, 0.893, 21.341},
+		},
+		{
+			{16039, 5.73945, 7.11355},
+			{4250, 4.5854, 213.2991},
+			{1907, 4.7608, 220.4126},
+			{1466, 5.9133, 206.1855},
+			{1162, 5.6197, 14.2271},
+			{1067, 3.6082, 426.5982},
+			{239, 3.861, 433.712},
+			{237, 5.768, 199.072},
+			{166, 5.116, 3.181},
+			{151, 2.736, 639.897},
+			{131, 4.743, 227.526},
+		},
+		{
+			{1662, 3.9983, 7.1355},
+			{257, 2.984, 220.413},
+			{236, 3.902, 14.227},
+			{149, 2.741, 213.299},
+			{114, 3.142, 0},
+			{110, 1.515, 206.186},
+			{68, 1.72, 426.60},
+			{40, 2.05, 433.71},
+			{38, 1.24, 199.07},
+			{31, 3.01, 227.53},
+		},
+		{
+			{124, 2.259, 7.114},
+			{34, 2.16, 14.23},
+			{28, 1.20, 220.41},
+			{6, 1.22, 227.53},
+			{5, 0.24, 433.71},
+			{4, 6.23, 426.60},
+			{3, 2.97, 199.07},
+			{3, 4.29, 206.19},
+			{2, 6.25, 213.30},
+		},
+	}
+
+	saturnLatitudeSeries = vsop87{
+		{
+			{4330678, 3.6028443, 213.2990954},
+			{240348, 2.852385, 426.598191},
+			{84746, 0, 0},
+			{34116, 0.57297, 206.18555},
+			{30863, 3.48442, 220.41264},
+			{14734, 2.11847, 639.89729},
+			{9917, 5.7900, 419.4846},
+			{6994, 4.7360, 7.1135},
+			{4808, 5.4331, 316.3919},
+			{4788, 4.9651, 110.2063},
+			{3432, 2.7326, 433.7117},
+			{1506, 6.0130, 103.0928},
+			{1060, 5.6310, 529.6910},
+			{969, 5.204, 632.784},
+			{942, 1.396, 853.196},
+			{708, 3.803, 323.505},
+			{552, 5.131, 202.253},
+			{400, 3.359, 227.526},
+			{319, 3.626, 209.367},
+			{316, 1.997, 647.011},
+			{314, 0.465, 217.231},
+			{284, 4.886, 224.345},
+			{236, 2.139, 11.046},
+			{215, 5.950, 846.083},
+			{209, 2.120, 415.552},
+			{207, 0.730, 199.072},
+			{179, 2.954, 63.736},
+			{141, 0.644, 490.334},
+			{139, 4.595, 14.227},
+			{139, 1.998, 735.877},
+			{135, 5.245, 742.990},
+			{122, 3.115, 522.577},
+			{116, 3.109, 216.480},
+			{114, 0.963, 210.118},
+		},
+		{
+			{397555, 5.332900, 213.299095},
+			{49479, 3.14159, 0},
+			{18572, 6.09919, 426.59819},
+			{14801, 2.30586, 206.18555},
+			{9644, 1.6967, 220.4126},
+			{3757, 1.2543, 419.4846},
+			{2717, 5.9117, 639.8973},
+			{1455, 0.8516, 433.7117},
+			{1291, 2.9177, 7.1135},
+			{853, 0.436, 316.392},
+			{298, 0.919, 632.784},
+			{292, 5.316, 853.196},
+			{284, 1.619, 227.526},
+			{275, 3.889, 103.093},
+			{172, 0.052, 647.011},
+			{166, 2.444, 199.072},
+			{158, 5.209, 110.206},
+			{128, 1.207, 529.691},
+			{110, 2.457, 217.231},
+		},
+		{
+			{20630, 0.50482, 213.29910},
+			{3720, 3.9983, 206.1855},
+			{1627, 6.1819, 220.4126},
+			{1346, 0, 0},
+			{706, 3.039, 419.485},
+			{365, 5.099, 426.598},
+			{330, 5.279, 433.712},
+			{219, 3.828, 639.897},
+			{139, 1.043, 7.114},
+			{104, 6.157, 227.526},
+		},
+		{
+			{666, 1.990, 213.299},
+			{632, 5.698, 206.186},
+			{398, 0, 0},
+			{188, 4.338, 220.413},
+			{92, 4.84, 419.48},
+			{52, 3.42, 433.71},
+			{42, 2.38, 426.60},
+			{26, 4.40, 227.53},
+			{21, 5.85, 199.07},
+			{18, 1.99, 639.90},
+		},
+		{
+			{80, 1.12, 206.19},
+			{32, 3.12, 213.30},
+			{17, 2.48, 220.41},
+			{12, 3.14, 0},
+			{9, 0.38, 419.48},
+			{6, 1.56, 433.71},
+			{5, 2.63, 227.53},
+			{5, 1.28, 199.07},
+		},
+		{
+			{8, 2.82, 206.19},
+			{1, 0.51, 220.41},
+		},
+	}
+
+	saturnDistanceSeries = vsop87{
+		{
+			{955758136, 0, 0},
+			{52921382, 2.39226220, 213.29909544},
+			{1873680, 5.2354961, 206.1855484},
+			{1464664, 1.6476305, 426.5981909},
+			{821891, 5.935200, 316.391870},
+			{547507, 5.015326, 103.092774},
+			{371684, 2.271148, 220.412642},
+			{361778, 3.139043, 7.113547},
+			{140618, 5.704067, 632.783739},
+			{108975, 3.293136, 110.206321},
+			{69007, 5.94100, 419.48464},
+			{61053, 0.94038, 639.89729},
+			{48913, 1.55733, 202.25340},
+			{34144, 0.19519, 277.03499},
+			{32402, 5.47085, 949.17561},
+			{20937, 0.46349, 735.87651},
+			{20839, 1.52103, 433.71174},
+			{20747, 5.33256, 199.07200},
+			{15298, 3.05944, 529.69097},
+			{14296, 2.60434, 323.50542},
+			{12884, 1.64892, 138.51750},
+			{11993, 5.98051, 846.08283},
+			{11380, 1.73106, 522.57742},
+			{9796, 5.2048, 1265.5675},
+			{7753, 5.8519, 95.9792},
+			{6771, 3.0043, 14.2271},
+			{6466, 0.1773, 1052.2684},
+			{5850, 1.4552, 415.5525},
+			{5307, 0.5974, 63.7359},
+			{4696, 2.1492, 227.5262},
+			{4044, 1.6401, 209.3669},
+			{3688, 0.7802, 412.3711},
+			{3461, 1.8509, 175.1661},
+			{3420, 4.9455, 1581.9593},
+			{3401, 0.5539, 350.3321},
+			{3376, 3.6953, 224.3448},
+			{2976, 5.6847, 210.1177},
+			{2885, 1.3876, 838.9693},
+			{2881, 0.1796, 853.1964},
+			{2508, 3.5385, 742.9901},
+			{2448, 6.1841, 1368.6603},
+			{2406, 2.9656, 117.3199},
+			{2174, 0.0151, 340.7709},
+			{2024, 5.0541, 11.0457},
+		},
+		{
+			{6182981, 0.2584352, 213.2990954},
+			{506578, 0.711147, 206.185548},
+			{341394, 5.796358, 426.598191},
+			{188491, 0.472157, 220.412642},
+			{186262, 3.141593, 0},
+			{143891, 1.407449, 7.113547},
+			{49621, 6.01744, 103.09277},
+			{20928, 5.09246, 639.89729},
+			{19953, 1.17560, 419.48464},
+			{18840, 1.60820, 110.20632},
+			{13877, 0.75886, 199.07200},
+			{12893, 5.94330, 433.71174},
+			{5397, 1.2885, 14.2271},
+			{4869, 0.8679, 323.5054},
+			{4247, 0.3930, 227.5262},
+			{3252, 1.2585, 95.9792},
+			{3081, 3.4366, 522.5774},
+			{2909, 4.6068, 202.2534},
+			{2856, 2.1673, 735.8765},
+			{1988, 2.4505, 412.3711},
+			{1941, 6.0239, 209.3669},
+			{1581, 1.2919, 210.1177},
+			{1340, 4.3080, 853.1964},
+			{1316, 1.2530, 117.3199},
+			{1203, 1.8665, 316.3919},
+			{1091, 0.0753, 216.4805},
+			{966, 0.480, 632.784},
+			{954, 5.152, 647.011},
+			{898, 0.983, 529.691},
+			{882, 1.885, 1052.268},
+			{874, 1.402, 224.345},
+			{785, 3.064, 838.969},
+			{740, 1.382, 625.670},
+			{658, 4.144, 309.278},
+			{650, 1.725, 742.990},
+			{613, 3.033, 63.736},
+			{599, 2.549, 217.231},
+			{503, 2.130, 3.932},
+		},
+		{
+			{436902, 4.786717, 213.299095},
+			{71923, 2.50070, 206.18555},
+			{49767, 4.97168, 220.41264},
+			{43221, 3.86940, 426.59819},
+			{29646, 5.96310, 7.11355},
+			{4721, 2.4753, 199.0720},
+			{4142, 4.1067, 433.7117},
+			{3789, 3.0977, 639.8973},
+			{2964, 1.3721, 103.0928},
+			{2556, 2.8507, 419.4846},
+			{2327, 0, 0},
+			{2208, 6.2759, 110.2063},
+			{2188, 5.8555, 14.2271},
+			{1957, 4.9245, 227.5262},
+			{924, 5.464, 323.505},
+			{706, 2.971, 95.979},
+			{546, 4.129, 412.371},
+			{431, 5.178, 522.577},
+			{405, 4.173, 209.367},
+			{391, 4.481, 216.480},
+			{374, 5.834, 117.320},
+			{361, 3.277, 647.011},
+			{356, 3.192, 210.118},
+			{326, 2.269, 853.196},
+			{207, 4.022, 735.877},
+			{204, 0.088, 202.253},
+			{180, 3.597, 632.784},
+			{178, 4.097, 440.825},
+			{154, 3.135, 625.670},
+			{148, 0.136, 302.165},
+			{133, 2.594, 191.958},
+			{132, 5.933, 309.278},
+		},
+		{
+			{20315, 3.02187, 213.29910},
+			{8924, 3.1914, 220.4126},
+			{6909, 4.3517, 206.1855},
+			{4087, 4.2241, 7.1135},
+			{3879, 2.0106, 426.5982},
+			{1071, 4.2036, 199.0720},
+			{907, 2.283, 433.712},
+			{606, 3.175, 227.526},
+			{597, 4.135, 14.227},
+			{483, 1.173, 639.897},
+			{393, 0, 0},
+			{229, 4.698, 419.485},
+			{188, 4.590, 110.206},
+			{150, 3.202, 103.093},
+			{121, 3.768, 323.505},
+			{102, 4.710, 95.979},
+			{101, 5.819, 412.371},
+		},
+		{
+			{1202, 1.4150, 220.4126},
+			{708, 1.162, 213.299},
+			{516, 6.240, 206.186},
+			{427, 2.469, 7.114},
+			{268, 0.187, 426.598},
+			{170, 5.959, 199.072},
+			{150, 0.480, 433.712},
+			{145, 1.442, 227.526},
+			{121, 2.405, 14.227},
+		},
+		{
+			{129, 5.913, 220.413},
+			{32, 0.69, 7.11},
+			{27, 5.91, 227.53},
+			{20, 4.95, 433.71},
+			{20, 0.67, 14.23},
+			{14, 2.67, 206.19},
+			{14, 1.46, 199.07},
+			{13, 4.59, 426.60},
+		},
+	}
+
+	uranusLongitudeSeries = vsop87{
+		{
+			{548129294, 0, 0},
+			{9260408, 0.8910642, 74.7815986},
+			{1504248, 3.6271926, 1.4844727},
+			{365982, 1.899622, 73.297126},
+			{272328, 3.358237, 149.563197},
+			{70328, 5.39254, 63.73590},
+			{68893, 6.09292, 76.26607},
+			{61999, 2.26952, 2.96895},
+			{61951, 2.85099, 11.04570},
+			{26469, 3.14152, 71.81265},
+			{25711, 6.11380, 454.90937},
+			{21079, 4.36059, 148.07872},
+			{17819, 1.74437, 36.64856},
+			{14613, 4.73732, 3.93215},
+			{11163, 5.82682, 224.34480},
+			{10998, 0.48865, 138.51750},
+			{9527, 2.9552, 35.1641},
+			{7546, 5.2363, 109.9457},
+			{4220, 3.2333, 70.8494},
+			{4052, 2.2775, 151.0477},
+			{3490, 5.4831, 146.5943},
+			{3355, 1.0655, 4.4534},
+			{3144, 4.7520, 77.7505},
+			{2927, 4.6290, 9.5612},
+			{2922, 5.3524, 85.8273},
+			{2273, 4.3660, 70.3282},
+			{2149, 0.6075, 38.1330},
+			{2051, 1.5177, 0.1119},
+			{1992, 4.9244, 277.0350},
+			{1667, 3.6274, 380.1278},
+			{1533, 2.5859, 52.6902},
+			{1376, 2.0428, 65.2204},
+			{1372, 4.1964, 111.4302},
+			{1284, 3.1135, 202.2534},
+			{1282, 0.5427, 222.8603},
+			{1244, 0.9161, 2.4477},
+			{1221, 0.1990, 108.4612},
+			{1151, 4.1790, 33.6796},
+			{1150, 0.9334, 3.1814},
+			{1090, 1.7750, 12.5302},
+			{1072, 0.2356, 62.2514},
+			{946, 1.192, 127.472},
+			{708, 5.183, 213.299},
+			{653, 0.966, 78.714},
+			{628, 0.182, 984.600},
+			{607, 5.432, 529.691},
+			{559, 3.358, 0.521},
+			{524, 2.013, 299.126},
+			{483, 2.106, 0.963},
+			{471, 1.407, 184.727},
+			{467, 0.415, 145.110},
+			{434, 5.521, 183.243},
+			{405, 5.987, 8.077},
+			{399, 0.338, 415.552},
+			{396, 5.870, 351.817},
+			{379, 2.350, 56.622},
+			{310, 5.833, 145.631},
+			{300, 5.644, 22.091},
+			{294, 5.839, 39.618},
+			{252, 1.637, 221.376},
+			{249, 4.746, 225.829},
+			{239, 2.350, 137.033},
+			{224, 0.516, 84.343},
+			{223, 2.843, 0.261},
+			{220, 1.922, 67.668},
+			{217, 6.142, 5.938},
+			{216, 4.778, 340.771},
+			{208, 5.580, 68.844},
+			{202, 1.297, 0.048},
+			{199, 0.956, 152.532},
+			{194, 1.888, 456.394},
+			{193, 0.916, 453.425},
+			{187, 1.319, 0.160},
+			{182, 3.536, 79.235},
+			{173, 1.539, 160.609},
+			{172, 5.680, 219.891},
+			{170, 3.677, 5.417},
+			{169, 5.879, 18.159},
+			{165, 1.424, 106.977},
+			{163, 3.050, 112.915},
+			{158, 0.738, 54.175},
+			{147, 1.263, 59.804},
+			{143, 1.300, 35.425},
+			{139, 5.386, 32.195},
+			{139, 4.260, 909.819},
+			{124, 1.374, 7.114},
+			{110, 2.027, 554.070},
+			{109, 5.706, 77.963},
+			{104, 5.028, 0.751},
+			{104, 1.458, 24.379},
+			{103, 0.681, 14.978},
+		},
+		{
+			{7502543122, 0, 0},
+			{154458, 5.242017, 74.781599},
+			{24456, 1.71256, 1.48447},
+			{9258, 0.4284, 11.0457},
+			{8266, 1.5022, 63.7359},
+			{7842, 1.3198, 149.5632},
+			{3899, 0.4648, 3.9322},
+			{2284, 4.1737, 76.2661},
+			{1927, 0.5301, 2.9689},
+			{1233, 1.5863, 70.8494},
+			{791, 5.436, 3.181},
+			{767, 1.996, 73.297},
+			{482, 2.984, 85.827},
+			{450, 4.138, 138.518},
+			{446, 3.723, 224.345},
+			{427, 4.731, 71.813},
+			{354, 2.583, 148.079},
+			{348, 2.454, 9.561},
+			{317, 5.579, 52.690},
+			{206, 2.363, 2.448},
+			{189, 4.202, 56.622},
+			{184, 0.284, 151.048},
+			{180, 5.684, 12.530},
+			{171, 3.001, 78.714},
+			{158, 2.909, 0.963},
+			{155, 5.591, 4.453},
+			{154, 4.652, 35.164},
+			{152, 2.942, 77.751},
+			{143, 2.590, 62.251},
+			{121, 4.148, 127.472},
+			{116, 3.732, 65.220},
+			{102, 4.188, 145.631},
+			{102, 6.034, 0.112},
+		},
+		{
+			{53033, 0, 0},
+			{2358, 2.2601, 74.7816},
+			{769, 4.526, 11.046},
+			{552, 3.258, 63.736},
+			{542, 2.276, 3.932},
+			{529, 4.923, 1.484},
+			{258, 3.691, 3.181},
+			{239, 5.858, 149.563},
+			{182, 6.218, 70.849},
+			{54, 1.44, 76.27},
+			{49, 6.03, 56.62},
+			{45, 3.91, 2.45},
+			{45, 0.81, 85.83},
+			{38, 1.78, 52.69},
+			{37, 4.46, 2.97},
+			{33, 0.86, 9.56},
+			{29, 5.10, 73.30},
+			{24, 2.11, 18.16},
+			{22, 5.99, 138.52},
+			{22, 4.82, 78.71},
+			{21, 2.40, 77.96},
+			{21, 2.17, 224.34},
+			{17, 2.54, 145.63},
+			{17, 3.47, 12.53},
+		},
+		{
+			{121, 0.024, 74.782},
+			{68, 4.12, 3.93},
+			{53, 2.39, 11.05},
+			{46, 0, 0},
+			{45, 2.04, 3.18},
+			{44, 2.96, 1.48},
+			{25, 4.89, 63.74},
+			{21, 4.55, 70.85},
+			{20, 2.31, 149.56},
+		},
+		{
+			{114, 3.142, 0},
+			{6, 4.58, 74.78},
+			{3, 0.35, 11.05},
+			{1, 3.42, 56.62},
+		},
+	}
+
+	uranusLatitudeSeries = vsop87{
+		{
+			{1346278, 2.6187781, 74.7815986},
+			{62341, 5.08111, 149.56320},
+			{61601, 3.14159, 0},
+			{9964, 1.6160, 76.2661},
+			{9926, 0.5763, 73.2971},
+			{3259, 1.2612, 224.3448},
+			{2972, 2.2437, 1.4845},
+			{2010, 6.0555, 148.0787},
+			{1522, 0.2796, 63.7359},
+			{924, 4.038, 151.048},
+			{761, 6.140, 71.813},
+			{522, 3.321, 138.518},
+			{463, 0.743, 85.827},
+			{437, 3.381, 529.691},
+			{435, 0.341, 77.751},
+			{431, 3.554, 213.299},
+			{420, 5.213, 11.046},
+			{245, 0.788, 2.969},
+			{233, 2.257, 222.860},
+			{216, 1.591, 38.133},
+			{180, 3.725, 299.126},
+			{175, 1.236, 146.594},
+			{174, 1.937, 380.128},
+			{160, 5.336, 111.430},
+			{144, 5.962, 35.164},
+			{116, 5.739, 70.849},
+			{106, 0.941, 70.328},
+			{102, 2.619, 78.714},
+		},
+		{
+			{206366, 4.123943, 74.781599},
+			{8563, 0.3382, 149.5632},
+			{1726, 2.1219, 73.2971},
+			{1374, 0, 0},
+			{1369, 3.0686, 76.2661},
+			{451, 3.777, 1.484},
+			{400, 2.848, 224.345},
+			{307, 1.255, 148.079},
+			{154, 3.786, 63.736},
+			{112, 5.573, 151.048},
+			{111, 5.329, 138.518},
+		},
+		{
+			{9212, 5.8004, 74.7816},
+			{557, 0, 0},
+			{286, 2.177, 149.563},
+			{95, 3.84, 73.30},
+			{45, 4.88, 76.27},
+			{20, 5.46, 1.48},
+			{15, 0.88, 138.52},
+			{14, 2.85, 148.08},
+			{14, 5.07, 63.74},
+			{10, 5.00, 224.34},
+			{8, 6.27, 78.71},
+		},
+		{
+			{268, 1.251, 74.782},
+			{11, 3.14, 0},
+			{6, 4.01, 149.56},
+			{3, 5.78, 73.30},
+		},
+		{
+			{6, 2.85, 74.78},
+		},
+	}
+
+	uranusDistanceSeries = vsop87{
+		{
+			{1921264848, 0, 0},
+			{88784984, 5.60377527, 74.78159857},
+			{3440836, 0.3283610, 73.2971259},
+			{2055653, 1.7829517, 149.5631971},
+			{649322, 4.522473, 76.266071},
+			{602248, 3.860038, 63.735898},
+			{496404, 1.401399, 454.909367},
+			{338526, 1.580027, 138.517497},
+			{243508, 1.570866, 71.812653},
+			{190522, 1.998094, 1.484473},
+			{161858, 2.791379, 148.078724},
+			{143706, 1.383686, 11.045700},
+			{93192, 0.17437, 36.64856},
+			{89806, 3.66105, 109.94569},
+			{71424, 4.24509, 224.34480},
+			{46677, 1.39977, 35.16409},
+			{39026, 3.36235, 277.03499},
+			{39010, 1.66971, 70.84945},
+			{36755, 3.88649, 146.59425},
+			{30349, 0.70100, 151.04767},
+			{29156, 3.18056, 77.75054},
+			{25786, 3.78538, 85.82730},
+			{25620, 5.25656, 380.12777},
+			{22637, 0.72519, 529.69097},
+			{20473, 2.79640, 70.32818},
+			{20472, 1.55589, 202.25340},
+			{17901, 0.55455, 2.96895},
+			{15503, 5.35405, 38.13304},
+			{14702, 4.90434, 108.46122},
+			{12897, 2.62154, 111.43016},
+			{12328, 5.96039, 127.47180},
+			{11959, 1.75044, 984.60033},
+			{11853, 0.99343, 52.69020},
+			{11696, 3.29826, 3.93215},
+			{11495, 0.43774, 65.22037},
+			{10793, 1.42105, 213.29910},
+			{9111, 4.9964, 62.2514},
+			{8421, 5.2535, 222.8603},
+			{8402, 5.0388, 415.5525},
+			{7449, 0.7949, 351.8166},
+			{7329, 3.9728, 183.2428},
+			{6046, 5.6796, 78.7138},
+			{5524, 3.1150, 9.5612},
+			{5445, 5.1058, 145.1098},
+			{5238, 2.6296, 33.6796},
+			{4079, 3.2206, 340.7709},
+			{3919, 4.2502, 39.6175},
+			{3802, 6.1099, 184.7273},
+			{3781, 3.4584, 456.3938},
+			{3687, 2.4872, 453.4249},
+			{3102, 4.1403, 219.8914},
+			{2963, 0.8298, 56.6224},
+			{2942, 0.4239, 299.1264},
+			{2940, 2.1464, 137.0330},
+			{2938, 3.6766, 140.0020},
+			{2865, 0.3100, 12.5302},
+			{2538, 4.8546, 131.4039},
+			{2364, 0.4425, 554.0700},
+			{2183, 2.9404, 305.3462},
+		},
+		{
+			{1479896, 3.6720571, 74.7815986},
+			{71212, 6.22601, 63.73590},
+			{68627, 6.13411, 149.56320},
+			{24060, 3.14159, 0},
+			{21468, 2.60177, 76.26607},
+			{20857, 5.24625, 11.04570},
+			{11405, 0.01848, 70.84945},
+			{7497, 0.4236, 73.2971},
+			{4244, 1.4169, 85.8273},
+			{3927, 3.1551, 71.8127},
+			{3578, 2.3116, 224.3448},
+			{3506, 2.5835, 138.5175},
+			{3229, 5.2550, 3.9322},
+			{3060, 0.1532, 1.4845},
+			{2564, 0.9808, 148.0787},
+			{2429, 3.9944, 52.6902},
+			{1645, 2.6535, 127.4718},
+			{1584, 1.4305, 78.7138},
+			{1508, 5.0600, 151.0477},
+			{1490, 2.6756, 56.6224},
+			{1413, 4.5746, 202.2534},
+			{1403, 1.3699, 77.7505},
+			{1228, 1.0470, 62.2514},
+			{1033, 0.2646, 131.4039},
+			{992, 2.172, 65.220},
+			{862, 5.055, 351.817},
+			{744, 3.076, 35.164},
+			{687, 2.499, 77.963},
+			{647, 4.473, 70.328},
+			{624, 0.863, 9.561},
+			{604, 0.907, 984.600},
+			{575, 3.231, 447.796},
+			{562, 2.718, 462.023},
+			{530, 5.917, 213.299},
+			{528, 5.151, 2.969},
+		},
+		{
+			{22440, 0.69953, 74.78160},
+			{4727, 1.6990, 63.7359},
+			{1682, 4.6483, 70.8494},
+			{1650, 3.0966, 11.0457},
+			{1434, 3.5212, 149.5632},
+			{770, 0, 0},
+			{500, 6.172, 76.266},
+			{461, 0.767, 3.932},
+			{390, 4.496, 56.622},
+			{390, 5.527, 85.827},
+			{292, 0.204, 52.690},
+			{287, 3.534, 73.297},
+			{273, 3.847, 138.518},
+			{220, 1.964, 131.404},
+			{216, 0.848, 77.963},
+			{205, 3.248, 78.714},
+			{149, 4.898, 127.472},
+			{129, 2.081, 3.181},
+		},
+		{
+			{1164, 4.7345, 74.7816},
+			{212, 3.343, 63.736},
+			{196, 2.980, 70.849},
+			{105, 0.958, 11.046},
+			{73, 1.00, 149.56},
+			{72, 0.03, 56.62},
+			{55, 2.59, 3.93},
+			{36, 5.65, 77.96},
+			{34, 3.82, 76.27},
+			{32, 3.60, 131.40},
+		},
+		{
+			{53, 3.01, 74.78},
+			{10, 1.91, 56.62},
+		},
+	}
+
+	neptuneLongitudeSeries = vsop87{
+		{
+			{531188633, 0, 0},
+			{1798476, 2.9010127, 38.1330356},
+			{1019728, 0.4858092, 1.4844727},
+			{124532, 4.830081, 36.648563},
+			{42064, 5.41055, 2.96895},
+			{37715, 6.09222, 35.16409},
+			{33785, 1.24489, 76.26607},
+			{16483, 0.00008, 491.55793},
+			{9199, 4.9375, 39.6175},
+			{8994, 0.2746, 175.1661},
+			{4216, 1.9871, 73.2971},
+			{3365, 1.0359, 33.6796},
+			{2285, 4.2061, 4.4534},
+			{1434, 2.7834, 74.7816},
+			{900, 2.076, 109.946},
+			{745, 3.190, 71.813},
+			{506, 5.748, 114.399},
+			{400, 0.350, 1021.249},
+			{345, 3.462, 41.102},
+			{340, 3.304, 77.751},
+			{323, 2.248, 32.195},
+			{306, 0.497, 0.521},
+			{287, 4.505, 0.048},
+			{282, 2.246, 146.594},
+			{267, 4.889, 0.963},
+			{252, 5.782, 388.465},
+			{245, 1.247, 9.561},
+			{233, 2.505, 137.033},
+			{227, 1.797, 453.425},
+			{170, 3.324, 108.461},
+			{151, 2.192, 33.940},
+			{150, 2.997, 5.938},
+			{148, 0.859, 111.430},
+			{119, 3.677, 2.448},
+			{109, 2.416, 183.243},
+			{103, 0.041, 0.261},
+			{103, 4.404, 70.328},
+			{102, 5.705, 0.112},
+		},
+		{
+			{3837687717, 0, 0},
+			{16604, 4.86319, 1.48447},
+			{15807, 2.27923, 38.13304},
+			{3335, 3.6820, 76.2661},
+			{1306, 3.6732, 2.9689},
+			{605, 1.505, 35.164},
+			{179, 3.453, 39.618},
+			{107, 2.451, 4.453},
+			{106, 2.755, 33.680},
+			{73, 5.49, 36.65},
+			{57, 1.86, 114.40},
+			{57, 5.22, 0.52},
+			{35, 4.52, 74.78},
+			{32, 5.90, 77.75},
+			{30, 3.67, 388.47},
+			{29, 5.17, 9.56},
+			{29, 5.17, 2.45},
+			{26, 5.25, 168.05},
+		},
+		{
+			{53893, 0, 0},
+			{296, 1.855, 1.484},
+			{281, 1.191, 38.133},
+			{270, 5.721, 76.266},
+			{23, 1.21, 2.97},
+			{9, 4.43, 35.16},
+			{7, 0.54, 2.45},
+		},
+		{
+			{31, 0, 0},
+			{15, 1.35, 76.27},
+			{12, 6.04, 1.48},
+			{12, 6.11, 38.13},
+		},
+		{
+			{114, 3.142, 0},
+		},
+	}
+
+	neptuneLatitudeSeries = vsop87{
+		{
+			{3088623, 1.4410437, 38.1330356},
+			{27780, 5.91272, 76.26607},
+			{27624, 0, 0},
+			{15448, 3.50877, 39.61751},
+			{15355, 2.52124, 36.64856},
+			{2000, 1.5100, 74.7816},
+			{1968, 4.3778, 1.4845},
+			{1015, 3.2156, 35.1641},
+			{606, 2.802, 73.297},
+			{595, 2.129, 41.102},
+			{589, 3.187, 2.969},
+			{402, 4.169, 114.399},
+			{280, 1.682, 77.751},
+			{262, 3.767, 213.299},
+			{254, 3.271, 453.425},
+			{206, 4.257, 529.691},
+			{140, 3.530, 137.033},
+		},
+		{
+			{227279, 3.807931, 38.133036},
+			{1803, 1.9758, 76.2661},
+			{1433, 3.1416, 0},
+			{1386, 4.8256, 36.6486},
+			{1073, 6.0805, 39.6175},
+			{148, 3.858, 74.782},
+			{136, 0.478, 1.484},
+			{70, 6.19, 35.16},
+			{52, 5.05, 73.30},
+			{43, 0.31, 114.40},
+			{37, 4.89, 41.10},
+			{37, 5.76, 2.97},
+			{26, 5.22, 213.30},
+		},
+		{
+			{9691, 5.5712, 38.1330},
+			{79, 3.63, 76.27},
+			{72, 0.45, 36.65},
+			{59, 3.14, 0},
+			{30, 1.61, 39.62},
+			{6, 5.61, 74.78},
+		},
+		{
+			{273, 1.017, 38.133},
+			{2, 0, 0},
+			{2, 2.37, 36.65},
+			{2, 5.33, 39.62},
+		},
+		{
+			{6, 2.67, 38.13},
+		},
+	}
+
+	neptuneDistanceSeries = vsop87{
+		{
+			{3007013206, 0, 0},
+			{27062259, 1.32999459, 38.13303564},
+			{1691764, 3.2518614, 36.6485629},
+			{807831, 5.185928, 1.484473},
+			{537761, 4.521139, 35.164090},
+			{495726, 1.571057, 491.557929},
+			{274572, 1.845523, 175.166060},
+			{135134, 3.372206, 39.617508},
+			{121802, 5.797544, 76.266071},
+			{100895, 0.377027, 73.297126},
+			{69792, 3.79617, 2.96895},
+			{46688, 5.74938, 33.67962},
+			{24594, 0.50802, 109.94569},
+			{16939, 1.59422, 71.81265},
+			{14230, 1.07786, 74.78160},
+			{12012, 1.92062, 1021.24889},
+			{8395, 0.6782, 146.5943},
+			{7572, 1.0715, 388.4652},
+			{5721, 2.5906, 4.4534},
+			{4840, 1.9069, 41.1020},
+			{4483, 2.9057, 529.6910},
+			{4421, 1.7499, 108.4612},
+			{4354, 0.6799, 32.1951},
+			{4270, 3.4134, 453.4249},
+			{3381, 0.8481, 183.2428},
+			{2881, 1.9860, 137.0330},
+			{2879, 3.6742, 350.3321},
+			{2636, 3.0976, 213.2991},
+			{2530, 5.7984, 490.0735},
+			{2523, 0.4863, 493.0424},
+			{2306, 2.8096, 70.3282},
+			{2087, 0.6186, 33.9402},
+		},
+		{
+			{236339, 0.704980, 38.133036},
+			{13220, 3.32015, 1.48447},
+			{8622, 6.2163, 35.1641},
+			{2702, 1.8814, 39.6175},
+			{2155, 2.0943, 2.9689},
+			{2153, 5.1687, 76.2661},
+			{1603, 0, 0},
+			{1464, 1.1842, 33.6796},
+			{1136, 3.9189, 36.6486},
+			{898, 5.241, 388.465},
+			{790, 0.533, 168.053},
+			{760, 0.021, 182.280},
+			{607, 1.077, 1021.249},
+			{572, 3.401, 484.444},
+			{561, 2.887, 498.671},
+		},
+		{
+			{4247, 5.8991, 38.1330},
+			{218, 0.346, 1.484},
+			{163, 2.239, 168.053},
+			{156, 4.594, 182.280},
+			{127, 2.848, 35.164},
+		},
+		{
+			{166, 4.552, 38.133},
+		},
+	}
+
+	// earthSeries and planetSeries gather the VSOP87 series for the longitude,
+	// latitude and distance of each planet
+	earthSeries = [3]vsop87{
+		earthLongitudeSeries, earthLatitudeSeries, earthDistanceSeries,
+	}
+	planetSeries = map[Planet][3]vsop87{
+		Mercury: {
+			mercuryLongitudeSeries, mercuryLatitudeSeries,
+			mercuryDistanceSeries,
+		},
+		Venus: {venusLongitudeSeries, venusLatitudeSeries, venusDistanceSeries},
+		Mars:  {marsLongitudeSeries, marsLatitudeSeries, marsDistanceSeries},
+		Jupiter: {
+			jupiterLongitudeSeries, jupiterLatitudeSeries,
+			jupiterDistanceSeries,
+		},
+		Saturn: {
+			saturnLongitudeSeries, saturnLatitudeSeries, saturnDistanceSeries,
+		},
+		Uranus: {
+			uranusLongitudeSeries, uranusLatitudeSeries, uranusDistanceSeries,
+		},
+		Neptune: {
+			neptuneLongitudeSeries, neptuneLatitudeSeries,
+			neptuneDistanceSeries,
+		},
+	}
+
+	// moonPhaseTerms are the periodic corrections (in days) given by Meeus in
+	// Astronomical Algorithms to the times of the mean new Moon, full Moon and
+	// quarters. Each term has its coefficient, the power of the eccentricity
+	// factor of the Earth's orbit by which it is multiplied, and the multiples
+	// of the mean anomalies of the Moon and Sun, the Moon's argument of
+	// latitude and the longitude of its ascending node in its argument.
+	moonPhaseTerms = map[PhaseName][]struct {
+		c              float64
+		e, mp, m, f, o float64
+	}{
+		NewMoon: {
+			{-0.40720, 0, 1, 0, 0, 0},
+			{0.17241, 1, 0, 1, 0, 0},
+			{0.01608, 0, 2, 0, 0, 0},
+			{0.01039, 0, 0, 0, 2, 0},
+			{0.00739, 1, 1, -1, 0, 0},
+			{-0.00514, 1, 1, 1, 0, 0},
+			{0.00208, 2, 0, 2, 0, 0},
+			{-0.00111, 0, 1, 0, -2, 0},
+			{-0.00057, 0, 1, 0, 2, 0},
+			{0.00056, 1, 2, 1, 0, 0},
+			{-0.00042, 0, 3, 0, 0, 0},
+			{0.00042, 1, 0, 1, 2, 0},
+			{0.00038, 1, 0, 1, -2, 0},
+			{-0.00024, 1, 2, -1, 0, 0},
+			{-0.00017, 0, 0, 0, 0, 1},
+			{-0.00007, 0, 1, 2, 0, 0},
+			{0.00004, 0, 2, 0, -2, 0},
+			{0.00004, 0, 0, 3, 0, 0},
+			{0.00003, 0, 1, 1, -2, 0},
+			{0.00003, 0, 2, 0, 2, 0},
+			{-0.00003, 0, 1, 1, 2, 0},
+			{0.00003, 0, 1, -1, 2, 0},
+			{-0.00002, 0, 1, -1, -2, 0},
+			{-0.00002, 0, 3, 1, 0, 0},
+			{0.00002, 0, 4, 0, 0, 0},
+		},
+		FullMoon: {
+			{-0.40614, 0, 1, 0, 0, 0},
+			{0.17302, 1, 0, 1, 0, 0},
+			{0.01614, 0, 2, 0, 0, 0},
+			{0.01043, 0, 0, 0, 2, 0},
+			{0.00734, 1, 1, -1, 0, 0},
+			{-0.00515, 1, 1, 1, 0, 0},
+			{0.00209, 2, 0, 2, 0, 0},
+			{-0.00111, 0, 1, 0, -2, 0},
+			{-0.00057, 0, 1, 0, 2, 0},
+			{0.00056, 1, 2, 1, 0, 0},
+			{-0.00042, 0, 3, 0, 0, 0},
+			{0.00042, 1, 0, 1, 2, 0},
+			{0.00038, 1, 0, 1, -2, 0},
+			{-0.00024, 1, 2, -1, 0, 0},
+			{-0.00017, 0, 0, 0, 0, 1},
+			{-0.00007, 0, 1, 2, 0, 0},
+			{0.00004, 0, 2, 0, -2, 0},
+			{0.00004, 0, 0, 3, 0, 0},
+			{0.00003, 0, 1, 1, -2, 0},
+			{0.00003, 0, 2, 0, 2, 0},
+			{-0.00003, 0, 1, 1, 2, 0},
+			{0.00003, 0, 1, -1, 2, 0},
+			{-0.00002, 0, 1, -1, -2, 0},
+			{-0.00002, 0, 3, 1, 0, 0},
+			{0.00002, 0, 4, 0, 0, 0},
+		},
+		FirstQuarter: {
+			{-0.62801, 0, 1, 0, 0, 0},
+			{0.17172, 1, 0, 1, 0, 0},
+			{-0.01183, 1, 1, 1, 0, 0},
+			{0.00862, 0, 2, 0, 0, 0},
+			{0.00804, 0, 0, 0, 2, 0},
+			{0.00454, 1, 1, -1, 0, 0},
+			{0.00204, 2, 0, 2, 0, 0},
+			{-0.00180, 0, 1, 0, -2, 0},
+			{-0.00070, 0, 1, 0, 2, 0},
+			{-0.00040, 0, 3, 0, 0, 0},
+			{-0.00034, 1, 2, -1, 0, 0},
+			{0.00032, 1, 0, 1, 2, 0},
+			{0.00032, 1, 0, 1, -2, 0},
+			{-0.00028, 2, 1, 2, 0, 0},
+			{0.00027, 1, 2, 1, 0, 0},
+			{-0.00017, 0, 0, 0, 0, 1},
+			{-0.00005, 0, 1, -1, -2, 0},
+			{0.00004, 0, 2, 0, 2, 0},
+			{-0.00004, 0, 1, 1, 2, 0},
+			{0.00004, 0, 1, -2, 0, 0},
+			{0.00003, 0, 1, 1, -2, 0},
+			{0.00003, 0, 0, 3, 0, 0},
+			{0.00002, 0, 2, 0, -2, 0},
+			{0.00002, 0, 1, -1, 2, 0},
+			{-0.00002, 0, 3, 1, 0, 0},
+		},
+	}
+
+	// moonPhasePlanetaryTerms are the further corrections (in days) to the
+	// times of all the phases of the Moon arising from the planets, each given
+	// by the constant and rate (in degrees per lunation) of its argument, then
+	// its coefficient
+	moonPhasePlanetaryTerms = []struct{ a, b, c float64 }{
+		{299.77, 0.107408, 0.000325},
+		{251.88, 0.016321, 0.000165},
+		{251.83, 26.651886, 0.000164},
+		{349.42, 36.412478, 0.000126},
+		{84.66, 18.206239, 0.000110},
+		{141.74, 53.303771, 0.000062},
+		{207.14, 2.453732, 0.000060},
+		{154.84, 7.306860, 0.000056},
+		{34.52, 27.261239, 0.000047},
+		{207.19, 0.121824, 0.000042},
+		{291.34, 1.844379, 0.000040},
+		{161.72, 24.198154, 0.000037},
+		{239.56, 25.513099, 0.000035},
+		{331.55, 3.592518, 0.000023},
+	}
+
+	// moonLongitudeDistanceTerms is the series for the Moon's longitude and
+	// distance given by Meeus in Astronomical Algorithms, after the ELP-2000/82
+	// theory. Each term has the multiples of the mean elongation of the Moon,
+	// the mean anomalies of the Sun and Moon and the Moon's argument of
+	// latitude in its argument, then the coefficients of the sine of the
+	// argument in longitude (in units of 0.000001 degrees) and of its cosine
+	// in distance (in meters).
+	moonLongitudeDistanceTerms = []struct {
+		d, m, mp, f float64
+		l, r        float64
+	}{
+		{0, 0, 1, 0, 6288774, -20905355},
+		{2, 0, -1, 0, 1274027, -3699111},
+		{2, 0, 0, 0, 658314, -2955968},
+		{0, 0, 2, 0, 213618, -569925},
+		{0, 1, 0, 0, -185116, 48888},
+		{0, 0, 0, 2, -114332, -3149},
+		{2, 0, -2, 0, 58793, 246158},
+		{2, -1, -1, 0, 57066, -152138},
+		{2, 0, 1, 0, 53322, -170733},
+		{2, -1, 0, 0, 45758, -204586},
+		{0, 1, -1, 0, -40923, -129620},
+		{1, 0, 0, 0, -34720, 108743},
+		{0, 1, 1, 0, -30383, 104755},
+		{2, 0, 0, -2, 15327, 10321},
+		{0, 0, 1, 2, -12528, 0},
+		{0, 0, 1, -2, 10980, 79661},
+		{4, 0, -1, 0, 10675, -34782},
+		{0, 0, 3, 0, 10034, -23210},
+		{4, 0, -2, 0, 8548, -21636},
+		{2, 1, -1, 0, -7888, 24208},
+		{2, 1, 0, 0, -6766, 30824},
+		{1, 0, -1, 0, -5163, -8379},
+		{1, 1, 0, 0, 4987, -16675},
+		{2, -1, 1, 0, 4036, -12831},
+		{2, 0, 2, 0, 3994, -10445},
+		{4, 0, 0, 0, 3861, -11650},
+		{2, 0, -3, 0, 3665, 14403},
+		{0, 1, -2, 0, -2689, -7003},
+		{2, 0, -1, 2, -2602, 0},
+		{2, -1, -2, 0, 2390, 10056},
+		{1, 0, 1, 0, -2348, 6322},
+		{2, -2, 0, 0, 2236, -9884},
+		{0, 1, 2, 0, -2120, 5751},
+		{0, 2, 0, 0, -2069, 0},
+		{2, -2, -1, 0, 2048, -4950},
+		{2, 0, 1, -2, -1773, 4130},
+		{2, 0, 0, 2, -1595, 0},
+		{4, -1, -1, 0, 1215, -3958},
+		{0, 0, 2, 2, -1110, 0},
+		{3, 0, -1, 0, -892, 3258},
+		{2, 1, 1, 0, -810, 2616},
+		{4, -1, -2, 0, 759, -1897},
+		{0, 2, -1, 0, -713, -2117},
+		{2, 2, -1, 0, -700, 2354},
+		{2, 1, -2, 0, 691, 0},
+		{2, -1, 0, -2, 596, 0},
+		{4, 0, 1, 0, 549, -1423},
+		{0, 0, 4, 0, 537, -1117},
+		{4, -1, 0, 0, 520, -1571},
+		{1, 0, -2, 0, -487, -1739},
+		{2, 1, 0, -2, -399, 0},
+		{0, 0, 2, -2, -381, -4421},
+		{1, 1, 1, 0, 351, 0},
+		{3, 0, -2, 0, -340, 0},
+		{4, 0, -3, 0, 330, 0},
+		{2, -1, 2, 0, 327, 0},
+		{0, 2, 1, 0, -323, 1165},
+		{1, 1, -1, 0, 299, 0},
+		{2, 0, 3, 0, 294, 0},
+		{2, 0, -1, -2, 0, 8752},
+	}
+
+	// moonLatitudeTerms is the series for the Moon's latitude, in the same
+	// form as moonLongitudeDistanceTerms, with the coefficients of the sine
+	// of the argument in latitude (in units of 0.000001 degrees)
+	moonLatitudeTerms = []struct {
+		d, m, mp, f float64
+		b           float64
+	}{
+		{0, 0, 0, 1, 5128122},
+		{0, 0, 1, 1, 280602},
+		{0, 0, 1, -1, 277693},
+		{2, 0, 0, -1, 173237},
+		{2, 0, -1, 1, 55413},
+		{2, 0, -1, -1, 46271},
+		{2, 0, 0, 1, 32573},
+		{0, 0, 2, 1, 17198},
+		{2, 0, 1, -1, 9266},
+		{0, 0, 2, -1, 8822},
+		{2, -1, 0, -1, 8216},
+		{2, 0, -2, -1, 4324},
+		{2, 0, 1, 1, 4200},
+		{2, 1, 0, -1, -3359},
+		{2, -1, -1, 1, 2463},
+		{2, -1, 0, 1, 2211},
+		{2, -1, -1, -1, 2065},
+		{0, 1, -1, -1, -1870},
+		{4, 0, -1, -1, 1828},
+		{0, 1, 0, 1, -1794},
+		{0, 0, 0, 3, -1749},
+		{0, 1, -1, 1, -1565},
+		{1, 0, 0, 1, -1491},
+		{0, 1, 1, 1, -1475},
+		{0, 1, 1, -1, -1410},
+		{0, 1, 0, -1, -1344},
+		{1, 0, 0, -1, -1335},
+		{0, 0, 3, 1, 1107},
+		{4, 0, 0, -1, 1021},
+		{4, 0, -1, 1, 833},
+		{0, 0, 1, -3, 777},
+		{4, 0, -2, 1, 671},
+		{2, 0, 0, -3, 607},
+		{2, 0, 2, -1, 596},
+		{2, -1, 1, -1, 491},
+		{2, 0, -2, 1, -451},
+		{0, 0, 3, -1, 439},
+		{2, 0, 2, 1, 422},
+		{2, 0, -3, -1, 421},
+		{2, 1, -1, 1, -366},
+		{2, 1, 0, 1, -351},
+		{4, 0, 0, 1, 331},
+		{2, -1, 1, 1, 315},
+		{2, -2, 0, -1, 302},
+		{0, 0, 1, 3, -283},
+		{2, 1, 1, -1, -229},
+		{1, 1, 0, -1, 223},
+		{1, 1, 0, 1, 223},
+		{0, 1, -2, -1, -220},
+		{2, 1, -1, -1, -220},
+		{1, 0, 1, 1, -185},
+		{2, -1, -2, -1, 181},
+		{0, 1, 2, 1, -177},
+		{4, 0, -2, -1, 176},
+		{4, -1, -1, -1, 166},
+		{1, 0, 1, -1, -164},
+		{4, 0, 1, -1, 132},
+		{1, 0, -1, -1, -119},
+		{4, -1, 0, -1, 115},
+		{2, -2, 0, 1, 107},
+	}
+
+	// leapSecondsExpiry is the julianTime until which leapSeconds is known to
+	// be complete. Before the table UTC is taken to be UT1, and DeltaT is
+	// used to relate it to TT. After it, DeltaT is shifted to carry on from
+	// the last tabulated difference between TT and UTC.
+	leapSecondsExpiry julianTime = 2461406.5
+
+	// DeltaTOverride, when set, is consulted by DeltaT before its own model,
+	// so that observed or more recently predicted values of ΔT can be used.
+	// It reports false for years for which it has no value. Like DUT1, it
+	// applies to the whole process and must be set before any calculations
+	// run concurrently. The function itself may be called from several
+	// goroutines at once.
+	DeltaTOverride func(year float64) (time.Duration, bool)
+
+	// StandardWeather is the Weather for which refraction is usually
+	// tabulated, and is assumed unless other Weather is given
+	StandardWeather = Weather{Temperature: 10, Pressure: 1010}
+
+	// ErrPolarDay is returned when the Sun stays above the horizon for the
+	// whole of a day
+	ErrPolarDay = errors.New("astro: the sun does not set on this day")
+
+	// ErrPolarNight is returned when the Sun stays below the horizon for the
+	// whole of a day
+	ErrPolarNight = errors.New("astro: the sun does not rise on this day")
+
+	// ErrNoCrossing is returned when the Sun does not pass through a
+	// requested elevation or azimuth during a day
+	ErrNoCrossing = errors.New("astro: the sun does not cross that " +
+		"position on this day")
+
+	// ErrCircumpolar is returned when a Body stays above the horizon for the
+	// whole of a day
+	ErrCircumpolar = errors.New("astro: the body does not set on this day")
+
+	// ErrNeverRises is returned when a Body stays below the horizon for the
+	// whole of a day
+	ErrNeverRises = errors.New("astro: the body does not rise on this day")
+
+	// ErrUnknownBody is returned for a Planet or Luminary that is not one of
+	// the declared constants
+	ErrUnknownBody = errors.New("astro: unknown body")
+
+	// ErrInvalidJulianDate is returned when text cannot be parsed as a
+	// JulianDate
+	ErrInvalidJulianDate = errors.New("astro: invalid Julian Date")
+
+	// ErrInvalidPhaseName is returned when text is not the name of a
+	// PhaseName
+	ErrInvalidPhaseName = errors.New("astro: invalid phase name")
+
+	// ErrSkippedDate is returned for dates that were dropped from the
+	// calendar when switching from the Julian to the Gregorian calendar
+	ErrSkippedDate = errors.New("astro: date was skipped by the reformation")
+
+	// CatholicReformation introduced the Gregorian calendar on 15 October
+	// 1582 in much of Catholic Europe
+	CatholicReformation = Reformation{2299160.5}
+
+	// BritishReformation introduced the Gregorian calendar on 14 September
+	// 1752 in Great Britain and its colonies
+	BritishReformation = Reformation{2361221.5}
+
+	// RussianReformation introduced the Gregorian calendar on 14 February
+	// 1918 in Russia
+	RussianReformation = Reformation{2421638.5}
 )
 
-var (
-	earthAngleOfTilt = 23.439281 // Appoximate value
+func (j julianTime) julianDay() julianDay {
+	return julianDay(math.Round(float64(j)))
+}
+
+// J2000Epoch returns the julianTime of a given julianTime within the standard
+// epoch "J2000" in the Julian calendar
+func (j julianTime) J2000Epoch() julianTime {
+	return j - j2000
+}
+
+// year provides the decimal year (for example 1990.5 for the middle of 1990)
+// of a julianTime
+func (j julianTime) year() float64 {
+	return 2000 + float64(j.J2000Epoch())/365.25
+}
+
+// gregorian provides a gregorianTime corresponding to the supplied julianTime,
+// to the nearest microsecond. A julianTime of zero gives the zero
+// gregorianTime.
+func (j julianTime) gregorian() gregorianTime {
+	if j == 0 {
+		return gregorianTime{}
+	}
+	return gregorianTime(j.utc())
+}
+
+// utc provides the time.Time in UTC of a julianTime, to the nearest
+// microsecond
+func (j julianTime) utc() time.Time {
+	y, m, d := j.calendar(true)
+	day, f := math.Modf(d)
+	return time.Date(y, time.Month(m), int(day), 0, 0, 0, 0, time.UTC).Add(
+		time.Duration(f * 86400 * 1e9).Round(time.Microsecond))
+}
+
+// NewJulianDate provides the JulianDate jd1 + jd2. As with the routines of
+// the IAU's SOFA library, the Julian Date may be split between the two in any
+// way, and splitting it into a whole number of days and a fraction of a day
+// preserves its full precision.
+func NewJulianDate(jd1, jd2 float64) JulianDate {
+	d1, d2 := math.Floor(jd1), math.Floor(jd2)
+	f := jd1 - d1 + jd2 - d2
+	return JulianDate{d1 + d2 + math.Floor(f), f - math.Floor(f)}
+}
+
+// JulianDateFromTime provides the JulianDate of the supplied instant, to the
+// nanosecond
+func JulianDateFromTime(t time.Time) JulianDate {
+	t = t.UTC()
+	y, m, d := t.Date()
+	midnight := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	return NewJulianDate(float64(calendarJulian(y, int(m), float64(d), true)),
+		float64(t.Sub(midnight))/float64(24*time.Hour))
+}
+
+// ParseJulianDate parses a JulianDate written as by String, such as
+// "JD 2451545.0", or as a Modified Julian Date, such as "MJD 51544.5"
+func ParseJulianDate(s string) (JulianDate, error) {
+	f := strings.Fields(s)
+	if len(f) != 2 {
+		return JulianDate{}, fmt.Errorf("%w: %q", ErrInvalidJulianDate, s)
+	}
+	d, r, err := parseDecimal(f[1])
+	if err != nil {
+		return JulianDate{}, fmt.Errorf("%w: %q", ErrInvalidJulianDate, s)
+	}
+	switch f[0] {
+	case "JD":
+		return NewJulianDate(d, r), nil
+	case "MJD":
+		return NewJulianDate(d+mjdOffset, r), nil
+	}
+	return JulianDate{}, fmt.Errorf("%w: %q", ErrInvalidJulianDate, s)
+}
+
+// parseDecimal parses a finite decimal number into its whole and fractional
+// parts, so that long fractions are not rounded to the precision of the whole.
+// The digit separators and hexadecimal forms of Go literals are refused.
+func parseDecimal(s string) (float64, float64, error) {
+	if strings.ContainsAny(s, "_xX") {
+		return 0, 0, strconv.ErrSyntax
+	}
+	i := strings.IndexByte(s, '.')
+	if i < 0 || strings.ContainsAny(s, "eE") {
+		v, err := strconv.ParseFloat(s, 64)
+		if err == nil && (math.IsNaN(v) || math.IsInf(v, 0)) {
+			err = strconv.ErrSyntax
+		}
+		return v, 0, err
+	}
+	w, sign := s[:i], 1.0
+	switch {
+	case strings.HasPrefix(w, "-"):
+		w, sign = w[1:], -1
+	case strings.HasPrefix(w, "+"):
+		w = w[1:]
+	}
+	if w == "" {
+		if i == len(s)-1 {
+			return 0, 0, strconv.ErrSyntax
+		}
+		w = "0"
+	}
+	d, err := strconv.ParseUint(w, 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	f, err := strconv.ParseFloat("0"+s[i:], 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return sign * float64(d), sign * f, nil
+}
+
+// Time provides the instant of a JulianDate in UTC, to the nearest
+// nanosecond
+func (j JulianDate) Time() time.Time {
+	y, m, d := julianTime(j.day).calendar(true)
+	return time.Date(y, time.Month(m), int(d), 12, 0, 0, 0, time.UTC).Add(
+		time.Duration(math.Round(j.fraction * float64(24*time.Hour))))
+}
+
+// Float64 provides a JulianDate as a single number, which resolves it to
+// around 20 microseconds
+func (j JulianDate) Float64() float64 {
+	return j.day + j.fraction
+}
+
+// Parts provides the whole number of days of a JulianDate, counted from
+// noon, and the fraction of a day since then
+func (j JulianDate) Parts() (float64, float64) {
+	return j.day, j.fraction
+}
+
+// Add provides the JulianDate a duration after a JulianDate
+func (j JulianDate) Add(d time.Duration) JulianDate {
+	day := 24 * time.Hour
+	return NewJulianDate(j.day+float64(d/day),
+		j.fraction+float64(d%day)/float64(day))
+}
+
+// Sub provides the duration between two JulianDates. As with time.Time.Sub,
+// durations too long to be held by a time.Duration are given as the maximum
+// (or minimum) one.
+func (j JulianDate) Sub(u JulianDate) time.Duration {
+	day := float64(24 * time.Hour)
+	d := math.Round((j.day-u.day)*day + (j.fraction-u.fraction)*day)
+	switch {
+	case d >= math.MaxInt64:
+		return math.MaxInt64
+	case d <= math.MinInt64:
+		return math.MinInt64
+	}
+	return time.Duration(d)
+}
+
+// MJD provides the Modified Julian Date of a JulianDate, which counts days
+// from midnight at the start of 17 November 1858
+func (j JulianDate) MJD() float64 {
+	return j.day - mjdOffset + j.fraction
+}
+
+// J2000Epoch provides the standard epoch J2000, 12:00 TT on 1 January 2000.
+// Like any JulianDate it is in UTC, so it falls at 11:58:55.816 UTC.
+func J2000Epoch() JulianDate {
+	return JulianDateFromTime(ConvertTime(
+		time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC), TT, UTC))
+}
+
+// J2000Centuries provides the number of Julian centuries of 36525 days of
+// TT from J2000Epoch to a JulianDate
+func (j JulianDate) J2000Centuries() float64 {
+	return float64(gregorianTime(j.Time()).since(TT)) / 36525
+}
+
+// j2000 provides the number of days from JD 2451545.0 to a JulianDate, which
+// are days from J2000Epoch if the JulianDate is read in TT
+func (j JulianDate) j2000() julianTime {
+	return julianTime(j.day-float64(j2000)) + julianTime(j.fraction)
+}
+
+func (j JulianDate) String() string {
+	return "JD " + j.decimal()
+}
+
+// decimal writes a JulianDate as a decimal number. The whole and fractional
+// parts are written separately, and the fraction is rounded to 15 places,
+// around 0.1 nanoseconds, which is as finely as a JulianDate resolves time.
+func (j JulianDate) decimal() string {
+	if j.day < 0 && j.fraction > 0 {
+		return strconv.FormatFloat(j.Float64(), 'f', -1, 64)
+	}
+	d, f := j.day, math.Round(j.fraction*1e15)
+	if f == 1e15 {
+		d, f = d+1, 0
+	}
+	s := strconv.FormatFloat(d, 'f', 0, 64)
+	if f == 0 {
+		return s + ".0"
+	}
+	return s + "." + strings.TrimRight(fmt.Sprintf("%015d", int64(f)), "0")
+}
+
+// MarshalText provides the text form of a JulianDate given by String
+func (j JulianDate) MarshalText() ([]byte, error) {
+	return []byte(j.String()), nil
+}
+
+// UnmarshalText parses a JulianDate from text accepted by ParseJulianDate
+func (j *JulianDate) UnmarshalText(b []byte) error {
+	v, err := ParseJulianDate(string(b))
+	if err != nil {
+		return err
+	}
+	*j = v
+	return nil
+}
+
+// MarshalJSON provides a JulianDate as a JSON number, written out in full
+func (j JulianDate) MarshalJSON() ([]byte, error) {
+	return []byte(j.decimal()), nil
+}
+
+// UnmarshalJSON parses a JulianDate from a JSON number, or from a JSON string
+// accepted by ParseJulianDate. JSON null leaves the JulianDate unchanged.
+func (j *JulianDate) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+	var s string
+	if json.Unmarshal(b, &s) == nil {
+		return j.UnmarshalText([]byte(s))
+	}
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidJulianDate, b)
+	}
+	d, r, err := parseDecimal(n.String())
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidJulianDate, b)
+	}
+	*j = NewJulianDate(d, r)
+	return nil
+}
+
+// dynamical provides the number of days of Terrestrial Time between
+// J2000Epoch and a julianTime in UTC
+func (j julianTime) dynamical() julianTime {
+	return j.convert(UTC, TT) - j2000
+}
+
+// convert provides the julianTime in one TimeScale of an instant given as a
+// julianTime in another
+func (j julianTime) convert(from, to TimeScale) julianTime {
+	t := time.Time(j.gregorian())
+	return j + julianTime(ConvertTime(t, from, to).Sub(t).Hours()/24)
+}
+
+// ConvertTime provides the date and time shown by a clock keeping one
+// TimeScale at the instant when a clock keeping another shows the date and
+// time of the supplied time. Leap seconds themselves cannot be represented by
+// a time.Time, so times within them are shifted to the following second.
+func ConvertTime(t time.Time, from, to TimeScale) time.Time {
+	return fromTAI(toTAI(t, from), to)
+}
+
+// toTAI provides the TAI reading of a time in the supplied TimeScale
+func toTAI(t time.Time, s TimeScale) time.Time {
+	switch s {
+	case TT:
+		return t.Add(-ttMinusTAI)
+	case UTC:
+		if !leapSecondEra(t) {
+			return t.Add(utcDeltaT(t) - ttMinusTAI)
+		}
+		return t.Add(taiMinusUTC(t))
+	case UT1:
+		if beforeUTC(t) {
+			return toTAI(t, UTC)
+		}
+		return toTAI(t.Add(-DUT1), UTC)
+	}
+	return t
+}
+
+// fromTAI provides the reading in the supplied TimeScale of a TAI time
+func fromTAI(t time.Time, s TimeScale) time.Time {
+	switch s {
+	case TT:
+		return t.Add(ttMinusTAI)
+	case UTC:
+		if !leapSecondEra(t) {
+			return t.Add(ttMinusTAI -
+				utcDeltaT(t.Add(ttMinusTAI-utcDeltaT(t))))
+		}
+		return t.Add(-taiMinusUTC(t.Add(-taiMinusUTC(t))))
+	case UT1:
+		if beforeUTC(t) {
+			return fromTAI(t, UTC)
+		}
+		return fromTAI(t, UTC).Add(DUT1)
+	}
+	return t
+}
+
+// leapSecondEra reports whether a time falls within the span of leapSeconds
+func leapSecondEra(t time.Time) bool {
+	j := gregorianTime(t).julian()
+	return j >= leapSeconds[0].start && j < leapSecondsExpiry
+}
+
+// beforeUTC reports whether a time falls before the start of leapSeconds,
+// when UTC is taken to be UT1
+func beforeUTC(t time.Time) bool {
+	return gregorianTime(t).julian() < leapSeconds[0].start
+}
+
+// utcDeltaT provides the difference TT - UTC at a time outside the span of
+// leapSeconds. Before it this is ΔT, and after it ΔT is shifted so as to
+// continue from the value at leapSecondsExpiry, with no jump.
+func utcDeltaT(t time.Time) time.Duration {
+	if beforeUTC(t) {
+		return deltaT(t)
+	}
+	e := time.Time(leapSecondsExpiry.gregorian())
+	return deltaT(t) - deltaT(e) + taiMinusUTC(e) + ttMinusTAI
+}
+
+// deltaT provides ΔT at the supplied time
+func deltaT(t time.Time) time.Duration {
+	return DeltaT(gregorianTime(t).julian().year())
+}
+
+// DeltaT provides ΔT, the difference TT - UT1, during the supplied decimal
+// year (for example 1990.5 for the middle of 1990). Unless DeltaTOverride
+// provides a value, it is given by the polynomial expressions of Espenak and
+// Meeus, which are fitted to historical observations and extrapolated beyond
+// them.
+func DeltaT(year float64) time.Duration {
+	if DeltaTOverride != nil {
+		if d, ok := DeltaTOverride(year); ok {
+			return d
+		}
+	}
+	return time.Duration(deltaTSeconds(year) * float64(time.Second))
+}
+
+// deltaTSeconds provides the Espenak and Meeus estimate of ΔT (in seconds)
+// during the supplied decimal year
+func deltaTSeconds(y float64) float64 {
+	switch {
+	case y < -500:
+		u := (y - 1820) / 100
+		return -20 + 32*u*u
+	case y < 500:
+		return polynomial(y/100, 10583.6, -1014.41, 33.78311, -5.952053,
+			-0.1798452, 0.022174192, 0.0090316521)
+	case y < 1600:
+		return polynomial((y-1000)/100, 1574.2, -556.01, 71.23472, 0.319781,
+			-0.8503463, -0.005050998, 0.0083572073)
+	case y < 1700:
+		return polynomial(y-1600, 120, -0.9808, -0.01532, 1.0/7129)
+	case y < 1800:
+		return polynomial(y-1700, 8.83, 0.1603, -0.0059285, 0.00013336,
+			-1.0/1174000)
+	case y < 1860:
+		return polynomial(y-1800, 13.72, -0.332447, 0.0068612, 0.0041116,
+			-0.00037436, 0.0000121272, -0.0000001699, 0.000000000875)
+	case y < 1900:
+		return polynomial(y-1860, 7.62, 0.5737, -0.251754, 0.01680668,
+			-0.0004473624, 1.0/233174)
+	case y < 1920:
+		return polynomial(y-1900, -2.79, 1.494119, -0.0598939, 0.0061966,
+			-0.000197)
+	case y < 1941:
+		return polynomial(y-1920, 21.20, 0.84493, -0.076100, 0.0020936)
+	case y < 1961:
+		return polynomial(y-1950, 29.07, 0.407, -1.0/233, 1.0/2547)
+	case y < 1986:
+		return polynomial(y-1975, 45.45, 1.067, -1.0/260, -1.0/718)
+	case y < 2005:
+		return polynomial(y-2000, 63.86, 0.3345, -0.060374, 0.0017275,
+			0.000651814, 0.00002373599)
+	case y < 2050:
+		return polynomial(y-2000, 62.92, 0.32217, 0.005589)
+	case y < 2150:
+		u := (y - 1820) / 100
+		return -20 + 32*u*u - 0.5628*(2150-y)
+	}
+	u := (y - 1820) / 100
+	return -20 + 32*u*u
+}
+
+// polynomial evaluates the polynomial in x with the supplied coefficients,
+// given in order of increasing power
+func polynomial(x float64, c ...float64) float64 {
+	r := 0.0
+	for i := len(c) - 1; i >= 0; i-- {
+		r = r*x + c[i]
+	}
+	return r
+}
+
+// taiMinusUTC provides the difference between TAI and the supplied UTC time,
+// which is taken to be zero before UTC was introduced in 1961
+func taiMinusUTC(t time.Time) time.Duration {
+	j := gregorianTime(t).julian()
+	for i := len(leapSeconds) - 1; i >= 0; i-- {
+		if l := leapSeconds[i]; j >= l.start {
+			return time.Duration((l.offset + (float64(j)-mjdOffset-l.mjd)*
+				l.rate) * float64(time.Second))
+		}
+	}
+	return 0
+}
+
+func (s TimeScale) String() string {
+	switch s {
+	case UTC:
+		return "UTC"
+	case TAI:
+		return "TAI"
+	case TT:
+		return "TT"
+	case UT1:
+		return "UT1"
+	}
+	return fmt.Sprintf("TimeScale(%d)", int(s))
+}
+
+func (p PhaseName) String() string {
+	switch p {
+	case NewMoon:
+		return "New Moon"
+	case WaxingCrescent:
+		return "Waxing Crescent"
+	case FirstQuarter:
+		return "First Quarter"
+	case WaxingGibbous:
+		return "Waxing Gibbous"
+	case FullMoon:
+		return "Full Moon"
+	case WaningGibbous:
+		return "Waning Gibbous"
+	case LastQuarter:
+		return "Last Quarter"
+	case WaningCrescent:
+		return "Waning Crescent"
+	}
+	return fmt.Sprintf("PhaseName(%d)", int(p))
+}
+
+// MarshalText encodes a PhaseName as its name
+func (p PhaseName) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText parses a PhaseName from its name
+func (p *PhaseName) UnmarshalText(b []byte) error {
+	for n := NewMoon; n <= WaningCrescent; n++ {
+		if n.String() == string(b) {
+			*p = n
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %q", ErrInvalidPhaseName, b)
+}
+
+func (j julianTime) IsZero() bool {
+	return math.IsNaN(float64(j))
+}
+
+// calendar converts a julianTime into a year, month and day (including any
+// fraction of a day) in the Gregorian calendar, or the Julian calendar if
+// gregorian is false. Years are numbered astronomically, so that 1 BC is year
+// 0. It uses the method given by Jean Meeus in Astronomical Algorithms, and
+// holds for julianTimes that are not negative.
+func (j julianTime) calendar(gregorian bool) (int, int, float64) {
+	z := math.Floor(float64(j) + 0.5)
+	a, f := z, float64(j)+0.5-z
+	if gregorian {
+		alpha := math.Floor((z - 1867216.25) / 36524.25)
+		a = z + 1 + alpha - math.Floor(alpha/4)
+	}
+	b := a + 1524
+	c := math.Floor((b - 122.1) / 365.25)
+	d := math.Floor(365.25 * c)
+	e := math.Floor((b - d) / 30.6001)
+	m := int(e) - 1
+	if e >= 14 {
+		m = int(e) - 13
+	}
+	y := int(c) - 4716
+	if m <= 2 {
+		y = int(c) - 4715
+	}
+	return y, m, b - d - math.Floor(30.6001*e) + f
+}
+
+// calendarJulian converts a year, month and day (including any fraction of a
+// day) in the Gregorian calendar, or the Julian calendar if gregorian is
+// false, into a julianTime. Years are numbered astronomically, so that 1 BC is
+// year 0. It uses the method given by Jean Meeus in Astronomical Algorithms,
+// and holds for dates from -4712 onwards.
+func calendarJulian(y, m int, d float64, gregorian bool) julianTime {
+	if m <= 2 {
+		y, m = y-1, m+12
+	}
+	b := 0.0
+	if gregorian {
+		a := math.Floor(float64(y) / 100)
+		b = 2 - a + math.Floor(a/4)
+	}
+	return julianTime(math.Floor(365.25*float64(y+4716)) +
+		math.Floor(30.6001*float64(m+1)) + d + b - 1524.5)
+}
+
+// NewJulianCalendarDate provides the date in the Julian calendar of the
+// calendar date of the supplied time in its own time zone
+func NewJulianCalendarDate(t time.Time) JulianCalendarDate {
+	return NewJulianCalendarDateFromJD(NewJulianDate(
+		float64(gregorianTime(t).julianDate()), 0))
+}
+
+// NewJulianCalendarDateFromJD provides the date in the Julian calendar on
+// which the supplied Julian Date falls
+func NewJulianCalendarDateFromJD(jd JulianDate) JulianCalendarDate {
+	y, m, d := julianTime(jd.Float64()).calendar(false)
+	return JulianCalendarDate{y, time.Month(m), int(d)}
+}
+
+// JD provides the Julian Date at the start (midnight UT) of a
+// JulianCalendarDate
+func (d JulianCalendarDate) JD() JulianDate {
+	return NewJulianDate(float64(d.julian()), 0)
+}
+
+// Time provides the time.Time at the start (midnight UTC) of a
+// JulianCalendarDate
+func (d JulianCalendarDate) Time() time.Time {
+	return time.Time(d.julian().gregorian())
+}
+
+func (d JulianCalendarDate) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, int(d.Month), d.Day)
+}
+
+func (d JulianCalendarDate) julian() julianTime {
+	return calendarJulian(d.Year, int(d.Month), float64(d.Day), false)
+}
+
+// NewReformation provides the Reformation that introduced the Gregorian
+// calendar on the calendar date of the supplied time
+func NewReformation(t time.Time) Reformation {
+	return Reformation{gregorianTime(t).julianDate() - 0.5}
+}
+
+// Time provides the time.Time at the start (midnight UTC) of a date as it
+// was written in a region that adopted the Gregorian calendar with a
+// Reformation. ErrSkippedDate is returned for dates that were dropped from the
+// calendar by the Reformation.
+func (r Reformation) Time(year int, month time.Month,
+	day int) (time.Time, error) {
+	j, err := r.julian(year, int(month), float64(day))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Time(j.gregorian()), nil
+}
+
+// Date provides the date as it was written on the calendar date of the
+// supplied time, in its own time zone, in a region that adopted the
+// Gregorian calendar with a Reformation
+func (r Reformation) Date(t time.Time) (int, time.Month, int) {
+	y, m, d := r.date(gregorianTime(t).julianDate())
+	return y, time.Month(m), int(d)
+}
+
+// date converts a julianTime into a year, month and day (including any
+// fraction of a day) in the calendar in use at the time: the Julian calendar
+// before the Reformation and the Gregorian calendar thereafter
+func (r Reformation) date(j julianTime) (int, int, float64) {
+	return j.calendar(j >= r.first)
+}
+
+// julian converts a year, month and day (including any fraction of a day) in
+// the calendar in use at the time into a julianTime, treating dates before
+// the Reformation as being in the Julian calendar
+func (r Reformation) julian(y, m int, d float64) (julianTime, error) {
+	if j := calendarJulian(y, m, d, true); j >= r.first {
+		return j, nil
+	}
+	if j := calendarJulian(y, m, d, false); j < r.first {
+		return j, nil
+	}
+	return 0, ErrSkippedDate
+}
+
+// julian converts a gregorianTime into a julianTime, treating it as an
+// instant in the proleptic Gregorian calendar used by time.Time
+func (g gregorianTime) julian() julianTime {
+	u := gregorianTime(time.Time(g).UTC())
+	y, m, d := u.date()
+	return calendarJulian(y, m, float64(d)+u.fractionalDay(), true)
+}
+
+// since provides the number of days from J2000Epoch to a gregorianTime, as
+// read on a clock keeping the supplied TimeScale. It goes by way of a
+// JulianDate so as to keep the full precision of the time.
+func (g gregorianTime) since(s TimeScale) julianTime {
+	return JulianDateFromTime(ConvertTime(time.Time(g), UTC, s)).j2000()
+}
+
+// julianDate provides the julianTime at noon on the calendar date of a
+// gregorianTime in its own time zone
+func (g gregorianTime) julianDate() julianTime {
+	y, m, d := g.date()
+	return calendarJulian(y, m, float64(d)+0.5, true)
+}
+
+// julianDay provides the julianDay of the calendar date of a gregorianTime in
+// its own time zone
+func (g gregorianTime) julianDay() julianDay {
+	return g.julianDate().julianDay()
+}
+
+func (g gregorianTime) String() string {
+	if !time.Time(g).IsZero() {
+		return time.Time(g).Format(jsonTimeFormat)
+	}
+	return jsonTimeNilValue
+}
+
+// MarshalJSON encodes a gregorianTime using jsonTimeFormat, or
+// jsonTimeNilValue if it is the zero time
+func (g gregorianTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(g.String())
+}
+
+// in provides the time.Time of a gregorianTime in the supplied time zone, to
+// the nearest second, leaving the zero time untouched
+func (g gregorianTime) in(l *time.Location) time.Time {
+	if time.Time(g).IsZero() {
+		return time.Time{}
+	}
+	return time.Time(g).Round(time.Second).In(l)
+}
+
+// SunTimes provides the times of sunrise, solar noon and sunset at a Location
+// on the calendar date of the supplied time, expressed in its time zone. If
+// the Sun neither rises nor sets that day, ErrPolarDay or ErrPolarNight is
+// returned alongside SunTimes holding only the solar noon.
+func (a Location) SunTimes(date time.Time, opts ...Option) (SunTimes,
+	error) {
+	if err := a.validate(); err != nil {
+		return SunTimes{}, err
+	}
+	j, l := gregorianTime(date).julianDay(), date.Location()
+	o := newOptions(opts)
+	s := SunTimes{SolarNoon: a.solarTransit(j).gregorian().in(l)}
+	if o.horizon != nil {
+		return a.horizonSunTimes(j, o.horizon, a.weather(o), s)
+	}
+	e := a.horizonElevation(a.weather(o))
+	if err := a.polarState(j, e); err != nil {
+		return s, err
+	}
+	s.Sunrise = a.risingTime(j, e).gregorian().in(l)
+	s.Sunset = a.settingTime(j, e).gregorian().in(l)
+	return s, nil
+}
+
+// horizonSunTimes completes SunTimes holding the SolarNoon of a particular
+// julianDay with the times at which the Sun appears over and disappears
+// behind a Horizon. If it does neither, ErrPolarDay or ErrPolarNight is
+// returned according to whether it is in sight at solar noon.
+func (a Location) horizonSunTimes(j julianDay, h Horizon, w Weather,
+	s SunTimes) (SunTimes, error) {
+	l := s.SolarNoon.Location()
+	rise := a.horizonCrossing(j, h, w, true)
+	set := a.horizonCrossing(j, h, w, false)
+	if rise == 0 && set == 0 {
+		if a.skylineOffset(a.solarTransit(j), h, w) > 0 {
+			return s, ErrPolarDay
+		}
+		return s, ErrPolarNight
+	}
+	s.Sunrise = rise.gregorian().in(l)
+	s.Sunset = set.gregorian().in(l)
+	return s, nil
+}
+
+// Twilight provides the times bounding civil, nautical and astronomical
+// twilight, along with the golden and blue hours, at a Location on the
+// calendar date of the supplied time, expressed in its time zone. Where the
+// Sun does not reach the elevation bounding a band of twilight that day,
+// its DawnDusk says whether the Sun stays above or below it, and any golden
+// or blue hour bounds that do not occur are left as the zero time.Time.
+func (a Location) Twilight(date time.Time) (Twilight, error) {
+	if err := a.validate(); err != nil {
+		return Twilight{}, err
+	}
+	j, l := gregorianTime(date).julianDay(), date.Location()
+	return Twilight{
+		Civil:        a.dawnDusk(j, civilTwilightElevation, l),
+		Nautical:     a.dawnDusk(j, nauticalTwilightElevation, l),
+		Astronomical: a.dawnDusk(j, astronomicalTwilightElevation, l),
+		MorningGoldenHour: Interval{
+			Start: a.risingTime(j, blueHourElevation).gregorian().in(l),
+			End:   a.risingTime(j, goldenHourElevation).gregorian().in(l),
+		},
+		EveningGoldenHour: Interval{
+			Start: a.settingTime(j, goldenHourElevation).gregorian().in(l),
+			End:   a.settingTime(j, blueHourElevation).gregorian().in(l),
+		},
+		MorningBlueHour: Interval{
+			Start: a.risingTime(j, civilTwilightElevation).gregorian().in(l),
+			End:   a.risingTime(j, blueHourElevation).gregorian().in(l),
+		},
+		EveningBlueHour: Interval{
+			Start: a.settingTime(j, blueHourElevation).gregorian().in(l),
+			End:   a.settingTime(j, civilTwilightElevation).gregorian().in(l),
+		},
+	}, nil
+}
+
+// dawnDusk provides the times at which the Sun passes through the supplied
+// elevation on a particular julianDay, expressed in the supplied time zone,
+// or else whether it stays above or below it
+func (a Location) dawnDusk(j julianDay, e float64, l *time.Location) DawnDusk {
+	switch a.polarState(j, e) {
+	case ErrPolarDay:
+		return DawnDusk{AlwaysAbove: true}
+	case ErrPolarNight:
+		return DawnDusk{AlwaysBelow: true}
+	}
+	return DawnDusk{
+		Dawn: a.risingTime(j, e).gregorian().in(l),
+		Dusk: a.settingTime(j, e).gregorian().in(l),
+	}
+}
+
+// MarshalJSON encodes DawnDusk using jsonTimeFormat, with jsonTimeNilValue
+// standing in for times that do not occur
+func (d DawnDusk) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Dawn        gregorianTime `json:"dawn"`
+		Dusk        gregorianTime `json:"dusk"`
+		AlwaysAbove bool          `json:"alwaysAbove"`
+		AlwaysBelow bool          `json:"alwaysBelow"`
+	}{gregorianTime(d.Dawn), gregorianTime(d.Dusk), d.AlwaysAbove,
+		d.AlwaysBelow})
+}
+
+// MarshalJSON encodes an Interval using jsonTimeFormat, with
+// jsonTimeNilValue standing in for times that do not occur
+func (i Interval) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Start gregorianTime `json:"start"`
+		End   gregorianTime `json:"end"`
+	}{gregorianTime(i.Start), gregorianTime(i.End)})
+}
+
+// MarshalJSON encodes SunTimes using jsonTimeFormat, with jsonTimeNilValue
+// standing in for times that do not occur
+func (s SunTimes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Sunrise   gregorianTime `json:"sunrise"`
+		SolarNoon gregorianTime `json:"solarNoon"`
+		Sunset    gregorianTime `json:"sunset"`
+	}{
+		gregorianTime(s.Sunrise),
+		gregorianTime(s.SolarNoon),
+		gregorianTime(s.Sunset),
+	})
+}
+
+// meanSolarNoon provides the Julian 2000 Epoch julianTime (in UTC) of the
+// mean solar noon for a given Location on a particlular julianDay. Longitude is
+// positive to the east, so mean solar noon comes earlier for eastern
+// Locations.
+func (a Location) meanSolarNoon(j julianDay) julianTime {
+	return julianTime(j).J2000Epoch() - julianTime(a.Longitude/360)
+}
+
+// dynamicalNoon provides the mean solar noon of a Location on a particular
+// julianDay as a number of days of Terrestrial Time from J2000Epoch, which is
+// the argument taken by the solar coordinates
+func (a Location) dynamicalNoon(j julianDay) julianTime {
+	return (j2000 + a.meanSolarNoon(j)).dynamical()
+}
+
+func (a Location) solarMeanAnomaly(j julianDay) float64 {
+	return a.dynamicalNoon(j).solarMeanAnomaly()
+}
+
+func (a Location) equationOfTheCentre(j julianDay) float64 {
+	return a.dynamicalNoon(j).equationOfTheCentre()
+}
+
+func (a Location) eclipticLongitude(j julianDay) float64 {
+	return a.dynamicalNoon(j).eclipticLongitude()
+}
+
+// solarMeanAnomaly provides the mean anomaly (in degrees) of the Sun at a
+// julianTime relative to J2000Epoch
+func (j julianTime) solarMeanAnomaly() float64 {
+	return math.Mod(357.5291+0.98560028*float64(j), 360)
+}
+
+// equationOfTheCentre provides the difference (in degrees) between the true
+// and mean anomalies of the Sun at a julianTime relative to J2000Epoch
+func (j julianTime) equationOfTheCentre() float64 {
+	sma, t := j.solarMeanAnomaly(), float64(j)/36525
+	return (1.914602-0.004817*t-0.000014*t*t)*sin(sma) +
+		(0.019993-0.000101*t)*sin(2*sma) + 0.000289*sin(3*sma)
+}
+
+// Seasons provides the instants of the equinoxes and solstices in a year, at
+// which the apparent ecliptic longitude of the Sun is a multiple of 90°
+func Seasons(year int) SeasonTimes {
+	return SeasonTimes{
+		MarchEquinox:     solarLongitudeTime(year, time.March, 0),
+		JuneSolstice:     solarLongitudeTime(year, time.June, 90),
+		SeptemberEquinox: solarLongitudeTime(year, time.September, 180),
+		DecemberSolstice: solarLongitudeTime(year, time.December, 270),
+	}
+}
+
+// MarshalJSON encodes SeasonTimes using jsonTimeFormat
+func (s SeasonTimes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		MarchEquinox     gregorianTime `json:"marchEquinox"`
+		JuneSolstice     gregorianTime `json:"juneSolstice"`
+		SeptemberEquinox gregorianTime `json:"septemberEquinox"`
+		DecemberSolstice gregorianTime `json:"decemberSolstice"`
+	}{
+		gregorianTime(s.MarchEquinox),
+		gregorianTime(s.JuneSolstice),
+		gregorianTime(s.SeptemberEquinox),
+		gregorianTime(s.DecemberSolstice),
+	})
+}
+
+// solarLongitudeTime provides the instant, to the nearest second, in or near
+// the 21st of a month at which the apparent ecliptic longitude of the Sun is
+// as supplied (in degrees), by the method of Meeus
+func solarLongitudeTime(year int, month time.Month, l float64) time.Time {
+	j := gregorianTime(time.Date(year, month, 21, 0, 0, 0, 0, time.UTC)).
+		since(TT)
+	for i := 0; i < 20; i++ {
+		e, _ := j.sunEcliptic()
+		d := julianTime(58 * sin(l-e.Longitude))
+		if j += d; math.Abs(float64(d)) < 1e-7 {
+			break
+		}
+	}
+	return time.Time((j2000 + j).convert(TT, UTC).gregorian().in(time.UTC))
+}
+
+// sunEcliptic provides the apparent geocentric Ecliptic coordinates of the
+// Sun and its distance (in kilometers) at a julianTime relative to
+// J2000Epoch in TT, from VSOP87. This is more accurate than
+// eclipticLongitude, at a greater cost.
+func (j julianTime) sunEcliptic() (Ecliptic, float64) {
+	h, r := heliocentric(earthSeries, j)
+	e := j.fk5(Ecliptic{mod360(h.Longitude + 180), -h.Latitude})
+	psi, _ := j.nutation()
+	e.Longitude = mod360(e.Longitude + psi - 20.4898/3600/r)
+	return e, r * astronomicalUnit
+}
+
+// heliocentric provides the heliocentric Ecliptic coordinates of a planet,
+// referred to the mean ecliptic and equinox of date, and its distance (in
+// astronomical units) from the Sun at a julianTime relative to J2000Epoch in
+// TT, from its VSOP87 series
+func heliocentric(s [3]vsop87, j julianTime) (Ecliptic, float64) {
+	tau := float64(j) / 365250
+	return Ecliptic{
+		Longitude: mod360(s[0].evaluate(tau) * 180 / math.Pi),
+		Latitude:  s[1].evaluate(tau) * 180 / math.Pi,
+	}, s[2].evaluate(tau)
+}
+
+// fk5 converts Ecliptic coordinates given by VSOP87 at a julianTime
+// relative to J2000Epoch in TT to the FK5 system
+func (j julianTime) fk5(e Ecliptic) Ecliptic {
+	t := float64(j) / 36525
+	l := e.Longitude - 1.397*t - 0.00031*t*t
+	return Ecliptic{
+		Longitude: e.Longitude + (-0.09033+0.03916*(cos(l)+sin(l))*
+			math.Tan(e.Latitude/180*math.Pi))/3600,
+		Latitude: e.Latitude + 0.03916*(cos(l)-sin(l))/3600,
+	}
+}
+
+// evaluate provides the sum of a VSOP87 series at the supplied time (in
+// Julian millennia from J2000Epoch in TT)
+func (v vsop87) evaluate(tau float64) float64 {
+	var s float64
+	for i := len(v) - 1; i >= 0; i-- {
+		var x float64
+		for _, n := range v[i] {
+			x += n.a * math.Cos(n.b+n.c*tau)
+		}
+		s = s*tau + x
+	}
+	return s / 1e8
+}
+
+// sunDistance provides the distance (in kilometers) between the centres of
+// the Earth and the Sun at a julianTime relative to J2000Epoch
+func (j julianTime) sunDistance() float64 {
+	e := j.earthEccentricity()
+	return 1.000001018 * (1 - e*e) /
+		(1 + e*cos(j.solarMeanAnomaly()+j.equationOfTheCentre())) *
+		astronomicalUnit
+}
+
+// earthEccentricity provides the eccentricity of the Earth's orbit at a
+// julianTime relative to J2000Epoch
+func (j julianTime) earthEccentricity() float64 {
+	return polynomial(float64(j)/36525, 0.016708634, -0.000042037,
+		-0.0000001267)
+}
+
+// eclipticLongitude provides the apparent ecliptic longitude (in degrees) of
+// the Sun at a julianTime relative to J2000Epoch, which is referred to the
+// true equinox and allows for aberration
+func (j julianTime) eclipticLongitude() float64 {
+	psi, _ := j.nutation()
+	return math.Mod(j.solarMeanLongitude()+j.equationOfTheCentre()+psi-
+		solarAberration, 360)
+}
+
+// solarMeanLongitude provides the mean longitude (in degrees, not normalised)
+// of the Sun at a julianTime relative to J2000Epoch
+func (j julianTime) solarMeanLongitude() float64 {
+	return j.solarMeanAnomaly() + 180 + j.perihelionLongitude()
+}
+
+// equationOfTime provides the angle (in degrees) by which the apparent Sun
+// is ahead of the mean Sun at a julianTime relative to J2000Epoch
+func (j julianTime) equationOfTime() float64 {
+	return mod360(j.solarMeanLongitude()-solarAberration-
+		j.solarRightAscension()+j.equationOfTheEquinoxes()+180) - 180
+}
+
+// EquationOfTime provides the amount by which apparent solar time (as shown
+// by a sundial) is ahead of mean solar time at the supplied instant
+func EquationOfTime(t time.Time) time.Duration {
+	return degreesToDuration(gregorianTime(t).since(TT).equationOfTime())
+}
+
+// MeanSolarTime provides the supplied instant in the local mean solar time
+// of a Location, in which the mean Sun crosses the meridian at noon. The
+// offset from UTC is rounded to the nearest second.
+func (a Location) MeanSolarTime(t time.Time) time.Time {
+	return t.In(time.FixedZone("LMT", zoneOffset(a.meanSolarOffset())))
+}
+
+// ApparentSolarTime provides the supplied instant in the local apparent
+// solar time of a Location, in which the true Sun crosses the meridian at
+// noon. The offset from UTC is rounded to the nearest second.
+func (a Location) ApparentSolarTime(t time.Time) time.Time {
+	return t.In(time.FixedZone("LAT",
+		zoneOffset(a.meanSolarOffset()+EquationOfTime(t))))
+}
+
+// FromMeanSolarTime provides the instant at which the clock in the local
+// mean solar time of a Location shows the date and time of day of the
+// supplied time, ignoring its time zone
+func (a Location) FromMeanSolarTime(t time.Time) time.Time {
+	return wallClock(t).Add(-a.meanSolarOffset())
+}
 
-	// unixEpoch is Thursday, 1 January 1970 UTC
-	unixEpoch = time.Unix(0, 0).UTC()
-)
+// FromApparentSolarTime provides the instant at which the clock in the local
+// apparent solar time of a Location shows the date and time of day of the
+// supplied time, ignoring its time zone
+func (a Location) FromApparentSolarTime(t time.Time) time.Time {
+	u := a.FromMeanSolarTime(t)
+	for i := 0; i < 3; i++ {
+		u = wallClock(t).Add(-a.meanSolarOffset() - EquationOfTime(u))
+	}
+	return u
+}
 
-func (j julianTime) julianDay() julianDay {
-	return julianDay(math.Round(float64(j)))
+// meanSolarOffset provides the difference between the local mean solar time
+// of a Location and UTC
+func (a Location) meanSolarOffset() time.Duration {
+	return degreesToDuration(a.Longitude)
 }
 
-// J2000Epoch returns the julianTime of a given julianTime within the standard
-// epoch "J2000" in the Julian calendar
-func (j julianTime) J2000Epoch() julianTime {
-	return j - J2000Epoch + 0.0008
+// zoneOffset provides a time.Duration in whole seconds, for use as the
+// offset of a time zone
+func zoneOffset(d time.Duration) int {
+	return int(d.Round(time.Second) / time.Second)
 }
 
-// gregorian provides a gregorianTime corresponding to the supplied julianTime
-func (j julianTime) gregorian() gregorianTime {
-	if t := j - gregorianTime(unixEpoch).julian(); j != 0 {
-		return gregorianTime(time.Unix(int64(t*86400), 0))
+// wallClock provides the date and time of day shown by a time.Time as if it
+// were in UTC
+func wallClock(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, t.Hour(), t.Minute(), t.Second(),
+		t.Nanosecond(), time.UTC)
+}
+
+// degreesToDuration converts an hour angle (in degrees) into the
+// corresponding amount of time, at 15 degrees to the hour
+func degreesToDuration(a float64) time.Duration {
+	return time.Duration(a * 240 * float64(time.Second))
+}
+
+// perihelionLongitude provides the longitude (in degrees) of the Earth's
+// perihelion, which advances by around 1.7° a century, at a julianTime
+// relative to J2000Epoch
+func (j julianTime) perihelionLongitude() float64 {
+	t := float64(j) / 36525
+	return 102.93735 + 1.71946*t + 0.00046*t*t
+}
+
+// solarDeclination provides the declination (in degrees) of the Sun at a
+// julianTime relative to J2000Epoch
+func (j julianTime) solarDeclination() float64 {
+	return j.sunEquatorial().Declination
+}
+
+// solarRightAscension provides the right ascension (in degrees) of the Sun at
+// a julianTime relative to J2000Epoch
+func (j julianTime) solarRightAscension() float64 {
+	return j.sunEquatorial().RightAscension
+}
+
+// sunEquatorial provides the Equatorial coordinates of the Sun at a
+// julianTime relative to J2000Epoch, neglecting its ecliptic latitude
+func (j julianTime) sunEquatorial() Equatorial {
+	return Ecliptic{j.eclipticLongitude(), 0}.Equatorial(j.trueObliquity())
+}
+
+// meanSiderealTime provides the Greenwich mean sidereal time (in degrees) at
+// a julianTime in UT1 relative to J2000Epoch
+func (j julianTime) meanSiderealTime() float64 {
+	d := float64(j)
+	t := d / 36525
+	return mod360(280.46061837 + 360.98564736629*d + 0.000387933*t*t -
+		t*t*t/38710000)
+}
+
+// apparentSiderealTime provides the Greenwich apparent sidereal time (in
+// degrees) at a gregorianTime
+func (g gregorianTime) apparentSiderealTime() float64 {
+	return mod360(g.since(UT1).meanSiderealTime() +
+		g.since(TT).equationOfTheEquinoxes())
+}
+
+// equationOfTheEquinoxes provides the nutation in right ascension (in
+// degrees) at a julianTime relative to J2000Epoch
+func (j julianTime) equationOfTheEquinoxes() float64 {
+	psi, eps := j.nutation()
+	return psi * cos(j.meanObliquity()+eps)
+}
+
+// nutation provides the nutation in longitude and in obliquity (both in
+// degrees) at a julianTime relative to J2000Epoch, from the IAU 1980 theory
+func (j julianTime) nutation() (float64, float64) {
+	t := float64(j) / 36525
+	d := polynomial(t, 297.85036, 445267.111480, -0.0019142, 1.0/189474)
+	m := polynomial(t, 357.52772, 35999.050340, -0.0001603, -1.0/300000)
+	mp := polynomial(t, 134.96298, 477198.867398, 0.0086972, 1.0/56250)
+	f := polynomial(t, 93.27191, 483202.017538, -0.0036825, 1.0/327270)
+	o := polynomial(t, 125.04452, -1934.136261, 0.0020708, 1.0/450000)
+	var psi, eps float64
+	for _, n := range nutationTerms {
+		a := n.d*d + n.m*m + n.mp*mp + n.f*f + n.o*o
+		psi += (n.psi + n.psiT*t) * sin(a)
+		eps += (n.eps + n.epsT*t) * cos(a)
 	}
-	return gregorianTime{}
+	return psi / 36e6, eps / 36e6
 }
 
-func (j julianTime) IsZero() bool {
-	return math.IsNaN(float64(j))
+// trueObliquity provides the obliquity of the ecliptic (in degrees),
+// including nutation, at a julianTime relative to J2000Epoch
+func (j julianTime) trueObliquity() float64 {
+	_, eps := j.nutation()
+	return j.meanObliquity() + eps
 }
 
-// julian converts a gregorianTime into a julianTime (for dates with years in
-// the range of 1801 to 2099, inclusive)
-func (g gregorianTime) julian() julianTime {
-	return julianTime(367*g.year() - float64(int(7*(float64(g.year())+
-		float64(int((g.month()+9)/12)))/4)) +
-		float64((275*int(g.month()))/9) + 1721013.5 + g.day() +
-		g.fractionalDay() + g.c19Correction())
+// meanObliquity provides the mean obliquity of the ecliptic (in degrees) at a
+// julianTime relative to J2000Epoch
+func (j julianTime) meanObliquity() float64 {
+	t := float64(j) / 36525
+	return polynomial(t, 23.439291111, -0.013004167, -1.639e-7, 5.036e-7)
 }
 
-// c19Correction supplies a value for correcting the conversion of
-// gregorianTimes to julianTimes depending on whether they are after
-// 28th February 1900
-func (g gregorianTime) c19Correction() float64 {
-	if 100*g.year()+g.month()-190002.5 < 0 {
-		return 1
+// GreenwichMeanSiderealTime provides the Greenwich mean sidereal time at the
+// supplied instant: the hour angle of the mean vernal equinox from the
+// meridian at Greenwich
+func GreenwichMeanSiderealTime(t time.Time) time.Duration {
+	return degreesToDuration(gregorianTime(t).since(UT1).meanSiderealTime())
+}
+
+// GreenwichApparentSiderealTime provides the Greenwich apparent sidereal time
+// at the supplied instant, which is measured from the true vernal equinox and
+// so includes the nutation in right ascension
+func GreenwichApparentSiderealTime(t time.Time) time.Duration {
+	return degreesToDuration(gregorianTime(t).apparentSiderealTime())
+}
+
+// LocalSiderealTime provides the apparent sidereal time on the meridian of a
+// Location at the supplied instant, which is the right ascension of the stars
+// crossing it
+func (a Location) LocalSiderealTime(t time.Time) time.Duration {
+	return degreesToDuration(mod360(gregorianTime(t).apparentSiderealTime() +
+		a.Longitude))
+}
+
+// SunPosition provides the geometric position of the centre of the Sun in
+// the sky above a Location at the supplied instant. If WithWeather is
+// supplied, its elevation is instead the apparent one, raised by refraction
+// unless the Sun is more than a degree below the horizon.
+func (a Location) SunPosition(t time.Time, opts ...Option) Horizontal {
+	h := a.sunPosition(gregorianTime(t).julian())
+	if w := newOptions(opts).weather; w != nil {
+		h = a.refract(h, *w)
 	}
-	return 0
+	return h
 }
 
-func (g gregorianTime) julianDate() julianTime {
-	y, m, d := g.date()
-	return julianTime(d - 32075 + (1461*(y+4800+(m-14)/12)/4 +
-		(367*(m-2-(m-14)/12*12)/12 - (3*(y+4900+(m-14)/12)/100)/4)))
+// refract raises a geometric Horizontal by refraction in the supplied
+// Weather. Refraction is left out more than a degree below the Location's
+// horizon, where nothing can be seen and the formula no longer holds.
+func (a Location) refract(h Horizontal, w Weather) Horizontal {
+	if h.Elevation < -1-a.Altitude.dip() {
+		return h
+	}
+	h.Elevation += RefractionFromTrue(h.Elevation, w)
+	h.Zenith = 90 - h.Elevation
+	return h
 }
 
-func (g gregorianTime) julianDay() julianDay {
-	return g.julian().julianDay()
+// sunPosition provides the geometric position of the centre of the Sun in
+// the sky above a Location at a julianTime in UTC
+func (a Location) sunPosition(j julianTime) Horizontal {
+	n := j.dynamical()
+	return a.horizontal(j.convert(UTC, UT1).J2000Epoch().meanSiderealTime()+
+		n.equationOfTheEquinoxes()+a.Longitude-
+		n.solarRightAscension(), n.solarDeclination())
 }
 
-func (g gregorianTime) String() string {
-	if !time.Time(g).IsZero() {
-		return time.Time(g).Format(jsonTimeFormat)
+// Position provides the geocentric position of the centre of a Body at the
+// supplied instant, referred to the true equator and equinox of date. It
+// panics if the Body is not a known Planet or Luminary.
+func Position(b Body, t time.Time) Coords {
+	j := gregorianTime(t).since(TT)
+	e, d := b.position(j)
+	return Coords{
+		Ecliptic:   e,
+		Equatorial: e.Equatorial(j.trueObliquity()),
+		Distance:   d,
 	}
-	return jsonTimeNilValue
 }
 
-// meanSolarNoon provides the Julian 2000 Epoch julianTime of the mean solar
-// noon for a given Location on a particlular julianDay
-func (a Location) meanSolarNoon(j julianDay) julianTime {
-	return julianTime(j).J2000Epoch() + julianTime(a.Longitude/360)
+// Position provides the topocentric position of the centre of a Body, as
+// seen from a Location at the supplied instant, which differs from the
+// geocentric one owing to parallax by up to a degree for the Moon and a few
+// arcseconds for the Sun and planets, and not at all for a Star. The
+// elevation is the geometric one unless WithWeather is supplied, when it is
+// raised by refraction as for SunPosition.
+func (a Location) Position(b Body, t time.Time, opts ...Option) Coords {
+	c := Position(b, t)
+	s := gregorianTime(t).apparentSiderealTime() + a.Longitude
+	if c.Distance > 0 {
+		v := c.Equatorial.vector().scale(c.Distance).
+			subtract(a.geocentric(s))
+		e := gregorianTime(t).since(TT).trueObliquity()
+		c.Equatorial, c.Distance = v.equatorial(), v.length()
+		c.Ecliptic = c.Equatorial.Ecliptic(e)
+	}
+	q := c.Equatorial
+	c.Horizontal = a.horizontal(s-q.RightAscension, q.Declination)
+	if w := newOptions(opts).weather; w != nil {
+		c.Horizontal = a.refract(c.Horizontal, *w)
+	}
+	return c
 }
 
-func (a Location) solarMeanAnomaly(j julianDay) float64 {
-	return math.Mod(357.5291+0.98560028*float64(a.meanSolarNoon(j)),
-		360)
+// MoonPosition provides the geocentric position of the centre of the Moon at
+// the supplied instant, referred to the true equator and equinox of date
+func MoonPosition(t time.Time) Coords {
+	return Position(Moon, t)
 }
 
-func (a Location) equationOfTheCentre(j julianDay) float64 {
-	sma := a.solarMeanAnomaly(j)
-	return 1.9148*math.Sin(a.Longitude) + 0.0200*math.Sin(2*sma) +
-		0.0003*math.Sin(3*sma)
+// MoonPosition provides the topocentric position of the centre of the Moon,
+// as seen from a Location at the supplied instant
+func (a Location) MoonPosition(t time.Time, opts ...Option) Coords {
+	return a.Position(Moon, t, opts...)
 }
 
-func (a Location) eclipticLongitude(j julianDay) float64 {
-	return math.Mod(a.solarMeanAnomaly(j)+a.equationOfTheCentre(j)+
-		180+102.9732, 360)
+// Heliocentric provides the heliocentric Ecliptic coordinates of a Planet,
+// referred to the mean ecliptic and equinox of date, and its distance (in
+// kilometers) from the Sun at the supplied instant. It panics if the Planet
+// is not one of the declared constants.
+func (p Planet) Heliocentric(t time.Time) (Ecliptic, float64) {
+	e, r := heliocentric(p.series(), gregorianTime(t).since(TT))
+	return e, r * astronomicalUnit
+}
+
+// series provides the VSOP87 series of a Planet, panicking if it has none
+func (p Planet) series() [3]vsop87 {
+	s, ok := planetSeries[p]
+	if !ok {
+		panic(fmt.Sprintf("%s: %s", ErrUnknownBody, p))
+	}
+	return s
+}
+
+// position provides the apparent geocentric Ecliptic coordinates of a Planet
+// and its distance (in kilometers) at a julianTime relative to J2000Epoch in
+// TT. The Planet is seen where it was when the light reaching the Earth left
+// it, displaced by the aberration of that light.
+func (p Planet) position(j julianTime) (Ecliptic, float64) {
+	h, r := heliocentric(earthSeries, j)
+	earth := h.vector().scale(r)
+	var v vector
+	for i, d := 0, julianTime(0); i < 3; i++ {
+		e, r := heliocentric(p.series(), j-d)
+		v = e.vector().scale(r).subtract(earth)
+		d = julianTime(lightTime * v.length())
+	}
+	e := j.aberration(j.fk5(v.ecliptic()))
+	psi, _ := j.nutation()
+	e.Longitude = mod360(e.Longitude + psi)
+	return e, v.length() * astronomicalUnit
+}
+
+// aberration displaces Ecliptic coordinates at a julianTime relative to
+// J2000Epoch in TT by the annual aberration of light
+func (j julianTime) aberration(e Ecliptic) Ecliptic {
+	h, _ := heliocentric(earthSeries, j)
+	sun, ecc, pi := h.Longitude+180, j.earthEccentricity(),
+		j.perihelionLongitude()
+	return Ecliptic{
+		Longitude: e.Longitude + aberrationConstant*
+			(ecc*cos(pi-e.Longitude)-cos(sun-e.Longitude))/cos(e.Latitude),
+		Latitude: e.Latitude - aberrationConstant*sin(e.Latitude)*
+			(sin(sun-e.Longitude)-ecc*sin(pi-e.Longitude)),
+	}
+}
+
+func (p Planet) String() string {
+	switch p {
+	case Mercury:
+		return "Mercury"
+	case Venus:
+		return "Venus"
+	case Mars:
+		return "Mars"
+	case Jupiter:
+		return "Jupiter"
+	case Saturn:
+		return "Saturn"
+	case Uranus:
+		return "Uranus"
+	case Neptune:
+		return "Neptune"
+	}
+	return fmt.Sprintf("Planet(%d)", int(p))
+}
+
+// position provides the apparent geocentric Ecliptic coordinates of a
+// Luminary and its distance (in kilometers) at a julianTime relative to
+// J2000Epoch in TT
+func (l Luminary) position(j julianTime) (Ecliptic, float64) {
+	switch l {
+	case Sun:
+		return j.sunEcliptic()
+	case Moon:
+		return j.moonEcliptic()
+	}
+	panic(fmt.Sprintf("%s: %s", ErrUnknownBody, l))
+}
+
+// position provides the apparent geocentric Ecliptic coordinates of a Star
+// at a julianTime relative to J2000Epoch in TT, and a distance of 0
+func (s Star) position(j julianTime) (Ecliptic, float64) {
+	q := precession(float64(j) / 36525).apply(s.Equatorial.vector()).
+		equatorial()
+	e := j.aberration(q.Ecliptic(j.meanObliquity()))
+	psi, _ := j.nutation()
+	e.Longitude = mod360(e.Longitude + psi)
+	return e, 0
+}
+
+func (s Star) String() string {
+	return s.Name
+}
+
+func (l Luminary) String() string {
+	switch l {
+	case Sun:
+		return "Sun"
+	case Moon:
+		return "Moon"
+	}
+	return fmt.Sprintf("Luminary(%d)", int(l))
+}
+
+// geocentric provides the position (in kilometers) of a Location relative to
+// the centre of the Earth, in the equatorial frame of date, when the local
+// sidereal time (in degrees) is as supplied. The Earth is taken to be the
+// reference ellipsoid of the IAU 1976 system.
+func (a Location) geocentric(s float64) vector {
+	h := float64(a.Altitude) / 1000 / earthRadius
+	u := math.Atan(earthPolarRatio*math.Tan(a.Latitude/180*math.Pi)) *
+		180 / math.Pi
+	x := cos(u) + h*cos(a.Latitude)
+	z := earthPolarRatio*sin(u) + h*sin(a.Latitude)
+	return vector{
+		x * cos(s) * earthRadius,
+		x * sin(s) * earthRadius,
+		z * earthRadius,
+	}
+}
+
+// MoonTimes provides the times of moonrise, moonset and the Moon's transit
+// of the meridian at a Location on the calendar date of the supplied time,
+// expressed in its time zone. The Moon rises about 50 minutes later each day,
+// so a date may have no moonrise or moonset, or occasionally two.
+func (a Location) MoonTimes(date time.Time, opts ...Option) (MoonTimes,
+	error) {
+	if err := a.validate(); err != nil {
+		return MoonTimes{}, err
+	}
+	o := newOptions(opts)
+	w, l := a.weather(o), date.Location()
+	y, mo, d := date.Date()
+	start := time.Date(y, mo, d, 0, 0, 0, 0, l)
+	end := time.Date(y, mo, d+1, 0, 0, 0, 0, l)
+	offset := func(t time.Time) float64 {
+		return a.moonOffset(t, o.horizon, w)
+	}
+	var m MoonTimes
+	m.Rise, m.Set = crossings(start, end, offset)
+	m.Transit, _ = crossings(start, end, func(t time.Time) float64 {
+		return a.localHourAngle(Moon, t)
+	})
+	for _, s := range [][]time.Time{m.Rise, m.Set, m.Transit} {
+		for i := range s {
+			s[i] = gregorianTime(s[i]).in(l)
+		}
+	}
+	if len(m.Rise) == 0 && len(m.Set) == 0 {
+		m.AlwaysUp = offset(start) > 0
+		m.AlwaysDown = !m.AlwaysUp
+	}
+	return m, nil
+}
+
+// RiseTransitSet provides the times at which a Body rises, transits the
+// meridian and sets at a Location on the calendar date of the supplied time,
+// expressed in its time zone. It rises and sets when the geometric elevation
+// of its centre is the supplied standardAltitude (in degrees), such as
+// StandardAltitudeStar. If the Body neither rises nor sets that day,
+// ErrCircumpolar or ErrNeverRises is returned alongside BodyTimes holding
+// only its transit. Other times that do not occur on that date are left as
+// the zero time.Time. ErrUnknownBody is returned for a Planet or Luminary
+// that is not one of the declared constants.
+func (a Location) RiseTransitSet(b Body, date time.Time,
+	standardAltitude float64) (BodyTimes, error) {
+	if err := a.validate(); err != nil {
+		return BodyTimes{}, err
+	}
+	if !known(b) {
+		return BodyTimes{}, ErrUnknownBody
+	}
+	l := date.Location()
+	y, mo, d := date.Date()
+	start := time.Date(y, mo, d, 0, 0, 0, 0, l)
+	end := time.Date(y, mo, d+1, 0, 0, 0, 0, l)
+	offset := func(t time.Time) float64 {
+		return a.Position(b, t).Horizontal.Elevation - standardAltitude
+	}
+	first := func(s []time.Time) time.Time {
+		if len(s) == 0 {
+			return time.Time{}
+		}
+		return gregorianTime(s[0]).in(l)
+	}
+	rise, set := crossings(start, end, offset)
+	transit, _ := crossings(start, end, func(t time.Time) float64 {
+		return a.localHourAngle(b, t)
+	})
+	r := BodyTimes{Rise: first(rise), Transit: first(transit), Set: first(set)}
+	if len(rise) == 0 && len(set) == 0 {
+		if offset(start) > 0 {
+			return r, ErrCircumpolar
+		}
+		return r, ErrNeverRises
+	}
+	return r, nil
+}
+
+// known reports whether the position of a Body can be found, which is not
+// the case for a Planet or Luminary outside the declared constants
+func known(b Body) bool {
+	switch b := b.(type) {
+	case Planet:
+		_, ok := planetSeries[b]
+		return ok
+	case Luminary:
+		return b == Sun || b == Moon
+	}
+	return b != nil
+}
+
+// MarshalJSON encodes BodyTimes using jsonTimeFormat, with jsonTimeNilValue
+// standing in for times that do not occur
+func (r BodyTimes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Rise    gregorianTime `json:"rise"`
+		Transit gregorianTime `json:"transit"`
+		Set     gregorianTime `json:"set"`
+	}{
+		gregorianTime(r.Rise),
+		gregorianTime(r.Transit),
+		gregorianTime(r.Set),
+	})
+}
+
+// MarshalJSON encodes MoonTimes using jsonTimeFormat
+func (m MoonTimes) MarshalJSON() ([]byte, error) {
+	times := func(s []time.Time) []gregorianTime {
+		g := make([]gregorianTime, len(s))
+		for i := range s {
+			g[i] = gregorianTime(s[i])
+		}
+		return g
+	}
+	return json.Marshal(struct {
+		Rise       []gregorianTime `json:"rise"`
+		Set        []gregorianTime `json:"set"`
+		Transit    []gregorianTime `json:"transit"`
+		AlwaysUp   bool            `json:"alwaysUp"`
+		AlwaysDown bool            `json:"alwaysDown"`
+	}{times(m.Rise), times(m.Set), times(m.Transit), m.AlwaysUp,
+		m.AlwaysDown})
+}
+
+// MoonPhase provides the phase of the Moon seen from the centre of the Earth
+// at the supplied instant
+func MoonPhase(t time.Time) LunarPhase {
+	return moonPhase(gregorianTime(t).since(TT), MoonPosition(t), vector{})
+}
+
+// MoonPhase provides the phase of the Moon seen from a Location at the
+// supplied instant, along with the angle of its bright limb measured from
+// the direction of the zenith
+func (a Location) MoonPhase(t time.Time) LunarPhase {
+	s := gregorianTime(t).apparentSiderealTime() + a.Longitude
+	m := a.MoonPosition(t)
+	p := moonPhase(gregorianTime(t).since(TT), m, a.geocentric(s))
+	h, d := s-m.Equatorial.RightAscension, m.Equatorial.Declination
+	q := atan2(sin(h), math.Tan(a.Latitude/180*math.Pi)*cos(d)-sin(d)*cos(h))
+	p.ZenithBrightLimb = mod360(p.BrightLimb - q)
+	return p
+}
+
+// moonPhase provides the LunarPhase at a julianTime relative to J2000Epoch
+// in TT of the Moon at the supplied Coords, seen by an observer at the
+// supplied position relative to the centre of the Earth
+func moonPhase(j julianTime, m Coords, o vector) LunarPhase {
+	sun := j.sunEquatorial().vector().scale(j.sunDistance()).subtract(o)
+	moon := m.Equatorial.vector().scale(m.Distance)
+	i := sun.subtract(moon).angle(moon.scale(-1))
+	e := mod360(m.Ecliptic.Longitude - j.eclipticLongitude())
+	s, q := sun.equatorial(), m.Equatorial
+	h := s.RightAscension - q.RightAscension
+	return LunarPhase{
+		Angle:        i,
+		Illumination: (1 + cos(i)) / 2,
+		Waxing:       e < 180,
+		Name:         PhaseName(int(mod360(e+22.5)/45) % 8),
+		Age:          float64(j - j.newMoon()),
+		BrightLimb: mod360(atan2(cos(s.Declination)*sin(h),
+			sin(s.Declination)*cos(q.Declination)-
+				cos(s.Declination)*sin(q.Declination)*cos(h))),
+	}
+}
+
+// MoonPhases provides the instants of the new Moons, first quarters, full
+// Moons and last quarters from the supplied time until (but not including)
+// the other, in order and expressed in the time zone of the first. They are
+// found to within a minute or so by the method of Meeus.
+func MoonPhases(from, to time.Time) iter.Seq[PhaseTime] {
+	return func(yield func(PhaseTime) bool) {
+		y := gregorianTime(from).julian().year()
+		for k := math.Floor((y-2000)*12.3685) - 1; ; k += 0.25 {
+			n, j := principalPhase(k)
+			p := PhaseTime{n, j.convert(TT, UTC).gregorian().in(from.Location())}
+			if !p.Time.Before(to) {
+				return
+			}
+			if !p.Time.Before(from) && !yield(p) {
+				return
+			}
+		}
+	}
+}
+
+// MarshalJSON encodes a PhaseTime using jsonTimeFormat
+func (p PhaseTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Phase PhaseName     `json:"phase"`
+		Time  gregorianTime `json:"time"`
+	}{p.Phase, gregorianTime(p.Time)})
+}
+
+// principalPhase provides the PhaseName and julianTime in TT of a principal
+// phase of the Moon, where k counts lunations from the new Moon of 6 January
+// 2000, so that a fraction of 0.25, 0.5 or 0.75 gives a first quarter, full
+// Moon or last quarter
+func principalPhase(k float64) (PhaseName, julianTime) {
+	t := k / 1236.85
+	jde := 2451550.09766 + 29.530588861*k +
+		t*t*polynomial(t, 0.00015437, -0.000000150, 0.00000000073)
+	e := polynomial(t, 1, -0.002516, -0.0000074)
+	m := 2.5534 + 29.10535670*k + t*t*polynomial(t, -0.0000014, -0.00000011)
+	mp := 201.5643 + 385.81693528*k +
+		t*t*polynomial(t, 0.0107582, 0.00001238, -0.000000058)
+	f := 160.7108 + 390.67050284*k +
+		t*t*polynomial(t, -0.0016118, -0.00000227, 0.000000011)
+	o := 124.7746 - 1.56375588*k + t*t*polynomial(t, 0.0020672, 0.00000215)
+	phase := PhaseName(int(mod360(k*360)/90) * 2)
+	terms := moonPhaseTerms[phase]
+	if phase == FirstQuarter || phase == LastQuarter {
+		terms = moonPhaseTerms[FirstQuarter]
+		w := 0.00306 - 0.00038*e*cos(m) + 0.00026*cos(mp) -
+			0.00002*cos(mp-m) + 0.00002*cos(mp+m) + 0.00002*cos(2*f)
+		if phase == LastQuarter {
+			w = -w
+		}
+		jde += w
+	}
+	for _, n := range terms {
+		jde += n.c * math.Pow(e, n.e) * sin(n.mp*mp+n.m*m+n.f*f+n.o*o)
+	}
+	for i, n := range moonPhasePlanetaryTerms {
+		a := n.a + n.b*k
+		if i == 0 {
+			a -= 0.009173 * t * t
+		}
+		jde += n.c * sin(a)
+	}
+	return phase, julianTime(jde)
+}
+
+// moonElongation provides the angle (in degrees) by which the geocentric
+// ecliptic longitude of the Moon is ahead of that of the Sun at a julianTime
+// relative to J2000Epoch
+func (j julianTime) moonElongation() float64 {
+	e, _ := j.moonEcliptic()
+	return mod360(e.Longitude - j.eclipticLongitude())
+}
+
+// newMoon provides the julianTime relative to J2000Epoch of the last new
+// Moon before a julianTime, stepping back by the elongation at the Moon's
+// mean rate until it is within a millisecond
+func (j julianTime) newMoon() julianTime {
+	n := j - julianTime(j.moonElongation()/360*synodicMonth)
+	for i := 0; i < 20; i++ {
+		d := julianTime((mod360(n.moonElongation()+180) - 180) / 360 *
+			synodicMonth)
+		n -= d
+		if math.Abs(float64(d)) < 1e-3/86400 {
+			break
+		}
+	}
+	return n
+}
+
+// moonOffset provides the angle (in degrees) by which the upper limb of the
+// Moon appears above the horizon from a Location at the supplied instant,
+// allowing for refraction in the supplied Weather. The horizon is a Horizon
+// if one is supplied, or else the sea horizon dipped by the Altitude.
+func (a Location) moonOffset(t time.Time, h Horizon, w Weather) float64 {
+	p := a.MoonPosition(t)
+	e := p.Horizontal.Elevation
+	horizon := -a.Altitude.dip()
+	if h != nil {
+		horizon = h.Elevation(p.Horizontal.Azimuth)
+	}
+	return e + RefractionFromTrue(e, w) +
+		asin(moonRadius/p.Distance) - horizon
+}
+
+// localHourAngle provides the hour angle (in degrees, between -180 and 180)
+// of a Body seen from a Location at the supplied instant, which passes
+// through 0 as the Body transits the meridian
+func (a Location) localHourAngle(b Body, t time.Time) float64 {
+	p := a.Position(b, t)
+	h := gregorianTime(t).apparentSiderealTime() + a.Longitude -
+		p.Equatorial.RightAscension
+	return mod360(h+180) - 180
+}
+
+// crossings provides the instants between start and end at which f passes
+// upwards and downwards through 0, found by sampling it every few minutes
+// and narrowing down each change of sign to within half a second
+func crossings(start, end time.Time, f func(time.Time) float64) (up,
+	down []time.Time) {
+	const step, precision = 10 * time.Minute, 500 * time.Millisecond
+	f0 := f(start)
+	for t := start; t.Before(end); {
+		next := t.Add(step)
+		if next.After(end) {
+			next = end
+		}
+		f1 := f(next)
+		if (f0 < 0) != (f1 < 0) {
+			lo, hi := t, next
+			for hi.Sub(lo) > precision {
+				m := lo.Add(hi.Sub(lo) / 2)
+				if (f(m) < 0) == (f0 < 0) {
+					lo = m
+				} else {
+					hi = m
+				}
+			}
+			c := lo.Add(hi.Sub(lo) / 2)
+			if f0 < 0 {
+				up = append(up, c)
+			} else {
+				down = append(down, c)
+			}
+		}
+		t, f0 = next, f1
+	}
+	return up, down
+}
+
+// moonEcliptic provides the apparent geocentric Ecliptic coordinates of the
+// Moon and its distance (in kilometers) at a julianTime relative to
+// J2000Epoch
+func (j julianTime) moonEcliptic() (Ecliptic, float64) {
+	t := float64(j) / 36525
+	lp := polynomial(t, 218.3164477, 481267.88123421, -0.0015786,
+		1.0/538841, -1.0/65194000)
+	d := polynomial(t, 297.8501921, 445267.1114034, -0.0018819,
+		1.0/545868, -1.0/113065000)
+	m := polynomial(t, 357.5291092, 35999.0502909, -0.0001536,
+		1.0/24490000)
+	mp := polynomial(t, 134.9633964, 477198.8675055, 0.0087414,
+		1.0/69699, -1.0/14712000)
+	f := polynomial(t, 93.2720950, 483202.0175233, -0.0036539,
+		-1.0/3526000, 1.0/863310000)
+	a1, a2 := 119.75+131.849*t, 53.09+479264.290*t
+	a3 := 313.45 + 481266.484*t
+	e := polynomial(t, 1, -0.002516, -0.0000074)
+	var sl, sr, sb float64
+	for _, n := range moonLongitudeDistanceTerms {
+		x := n.d*d + n.m*m + n.mp*mp + n.f*f
+		k := math.Pow(e, math.Abs(n.m))
+		sl += n.l * k * sin(x)
+		sr += n.r * k * cos(x)
+	}
+	for _, n := range moonLatitudeTerms {
+		sb += n.b * math.Pow(e, math.Abs(n.m)) *
+			sin(n.d*d+n.m*m+n.mp*mp+n.f*f)
+	}
+	sl += 3958*sin(a1) + 1962*sin(lp-f) + 318*sin(a2)
+	sb += -2235*sin(lp) + 382*sin(a3) + 175*sin(a1-f) + 175*sin(a1+f) +
+		127*sin(lp-mp) - 115*sin(lp+mp)
+	psi, _ := j.nutation()
+	return Ecliptic{
+		Longitude: mod360(lp + sl/1e6 + psi),
+		Latitude:  sb / 1e6,
+	}, 385000.56 + sr/1000
+}
+
+// SunCrossing provides the time at which the centre of the Sun climbs (if
+// rising is true) or sinks through the supplied elevation (in degrees) at a
+// Location on the calendar date of the supplied time, expressed in its time
+// zone. ErrNoCrossing is returned if the Sun does not reach that elevation.
+func (a Location) SunCrossing(date time.Time, elevation float64,
+	rising bool) (time.Time, error) {
+	if err := a.validate(); err != nil {
+		return time.Time{}, err
+	}
+	j := gregorianTime(date).julianDay()
+	if a.polarState(j, elevation) != nil {
+		return time.Time{}, ErrNoCrossing
+	}
+	t := a.settingTime(j, elevation)
+	if rising {
+		t = a.risingTime(j, elevation)
+	}
+	return a.elevationCrossing(t, elevation).gregorian().in(date.Location()),
+		nil
+}
+
+// SunAzimuthCrossing provides the first time at which the Sun passes through
+// the supplied azimuth (in degrees clockwise from north) at a Location during
+// the day centred on solar noon on the calendar date of the supplied time,
+// expressed in its time zone. ErrNoCrossing is returned if the Sun does not
+// pass through that azimuth.
+func (a Location) SunAzimuthCrossing(date time.Time,
+	azimuth float64) (time.Time, error) {
+	if err := a.validate(); err != nil {
+		return time.Time{}, err
+	}
+	const step = julianTime(10.0 / 1440)
+	start := a.solarTransit(gregorianTime(date).julianDay()) - 0.5
+	d0 := a.azimuthOffset(start, azimuth)
+	for t := start; t < start+1; t += step {
+		d1 := a.azimuthOffset(t+step, azimuth)
+		if (d0 < 0) != (d1 < 0) && math.Abs(d1-d0) < 180 {
+			return a.azimuthCrossing(t, t+step, azimuth).gregorian().
+				in(date.Location()), nil
+		}
+		d0 = d1
+	}
+	return time.Time{}, ErrNoCrossing
+}
+
+// elevationCrossing refines an estimate of the julianTime at which the Sun
+// passes through the supplied elevation using Newton's method
+func (a Location) elevationCrossing(j julianTime, e float64) julianTime {
+	const step, precision = julianTime(30.0 / 86400), 0.5 / 86400
+	for i := 0; i < 10; i++ {
+		rate := (a.sunPosition(j+step).Elevation -
+			a.sunPosition(j-step).Elevation) / float64(2*step)
+		if rate == 0 {
+			break
+		}
+		d := julianTime((e - a.sunPosition(j).Elevation) / rate)
+		if j += d; math.Abs(float64(d)) < precision {
+			break
+		}
+	}
+	return j
+}
+
+// azimuthCrossing narrows down the julianTime at which the Sun passes through
+// the supplied azimuth, given julianTimes either side of it
+func (a Location) azimuthCrossing(lo, hi julianTime, az float64) julianTime {
+	const precision = 0.5 / 86400
+	below := a.azimuthOffset(lo, az) < 0
+	for hi-lo > precision {
+		if m := (lo + hi) / 2; (a.azimuthOffset(m, az) < 0) == below {
+			lo = m
+		} else {
+			hi = m
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// azimuthOffset provides the angle (in degrees, in the range [-180, 180))
+// from the supplied azimuth round to the Sun's azimuth at a julianTime
+func (a Location) azimuthOffset(j julianTime, az float64) float64 {
+	return mod360(a.sunPosition(j).Azimuth-az+180) - 180
+}
+
+// horizontal converts an hour angle and declination (both in degrees) into
+// Horizontal coordinates as seen from a Location
+func (a Location) horizontal(h, dec float64) Horizontal {
+	e := asin(sin(a.Latitude)*sin(dec) + cos(a.Latitude)*cos(dec)*cos(h))
+	return Horizontal{
+		Azimuth: mod360(atan2(-cos(dec)*sin(h),
+			sin(dec)*cos(a.Latitude)-cos(dec)*cos(h)*sin(a.Latitude))),
+		Elevation: e,
+		Zenith:    90 - e,
+	}
+}
+
+// Horizontal converts Equatorial coordinates into the position in the sky
+// of the object at them, as seen from a Location at the supplied instant
+func (a Location) Horizontal(q Equatorial, t time.Time) Horizontal {
+	return a.horizontal(gregorianTime(t).apparentSiderealTime()+a.Longitude-
+		q.RightAscension, q.Declination)
+}
+
+// Equatorial converts a position in the sky, as seen from a Location at the
+// supplied instant, into Equatorial coordinates
+func (a Location) Equatorial(h Horizontal, t time.Time) Equatorial {
+	dec := asin(sin(a.Latitude)*sin(h.Elevation) +
+		cos(a.Latitude)*cos(h.Elevation)*cos(h.Azimuth))
+	ha := atan2(-sin(h.Azimuth)*cos(h.Elevation), cos(a.Latitude)*
+		sin(h.Elevation)-sin(a.Latitude)*cos(h.Elevation)*cos(h.Azimuth))
+	return Equatorial{
+		RightAscension: mod360(gregorianTime(t).apparentSiderealTime() +
+			a.Longitude - ha),
+		Declination: dec,
+	}
+}
+
+// Equatorial converts Ecliptic coordinates into Equatorial coordinates,
+// given the obliquity of the ecliptic (in degrees)
+func (e Ecliptic) Equatorial(obliquity float64) Equatorial {
+	return Equatorial{
+		RightAscension: mod360(atan2(sin(e.Longitude)*cos(obliquity)*
+			cos(e.Latitude)-sin(e.Latitude)*sin(obliquity),
+			cos(e.Longitude)*cos(e.Latitude))),
+		Declination: asin(sin(e.Latitude)*cos(obliquity) +
+			cos(e.Latitude)*sin(obliquity)*sin(e.Longitude)),
+	}
+}
+
+// Ecliptic converts Equatorial coordinates into Ecliptic coordinates, given
+// the obliquity of the ecliptic (in degrees)
+func (q Equatorial) Ecliptic(obliquity float64) Ecliptic {
+	return Ecliptic{
+		Longitude: mod360(atan2(sin(q.RightAscension)*cos(obliquity)*
+			cos(q.Declination)+sin(q.Declination)*sin(obliquity),
+			cos(q.RightAscension)*cos(q.Declination))),
+		Latitude: asin(sin(q.Declination)*cos(obliquity) -
+			cos(q.Declination)*sin(obliquity)*sin(q.RightAscension)),
+	}
+}
+
+// Galactic converts Equatorial coordinates, referred to the equinox of
+// J2000, into Galactic coordinates
+func (q Equatorial) Galactic() Galactic {
+	h := q.RightAscension - galacticPoleRightAscension
+	return Galactic{
+		Longitude: mod360(celestialPoleGalacticLongitude -
+			atan2(cos(q.Declination)*sin(h),
+				sin(q.Declination)*cos(galacticPoleDeclination)-
+					cos(q.Declination)*sin(galacticPoleDeclination)*cos(h))),
+		Latitude: asin(sin(q.Declination)*sin(galacticPoleDeclination) +
+			cos(q.Declination)*cos(galacticPoleDeclination)*cos(h)),
+	}
+}
+
+// Equatorial converts Galactic coordinates into Equatorial coordinates,
+// referred to the equinox of J2000
+func (g Galactic) Equatorial() Equatorial {
+	l := celestialPoleGalacticLongitude - g.Longitude
+	return Equatorial{
+		RightAscension: mod360(galacticPoleRightAscension +
+			atan2(cos(g.Latitude)*sin(l),
+				sin(g.Latitude)*cos(galacticPoleDeclination)-
+					cos(g.Latitude)*sin(galacticPoleDeclination)*cos(l))),
+		Declination: asin(sin(g.Latitude)*sin(galacticPoleDeclination) +
+			cos(g.Latitude)*cos(galacticPoleDeclination)*cos(l)),
+	}
+}
+
+// Precess converts Equatorial coordinates referred to the mean equator and
+// equinox of one JulianDate into those of another, using the IAU 2006
+// precession. Either date is usually J2000Epoch.
+func Precess(coord Equatorial, from, to JulianDate) Equatorial {
+	return precession(to.J2000Centuries()).
+		multiply(precession(from.J2000Centuries()).transpose()).
+		apply(coord.vector()).equatorial()
+}
+
+// precession provides the IAU 2006 precession matrix, which rotates vectors
+// from the mean equator and equinox of J2000 to those of a date t Julian
+// centuries of TT from J2000Epoch
+func precession(t float64) matrix {
+	zeta := polynomial(t, 2.650545, 2306.083227, 0.2988499, 0.01801828,
+		-0.000005971, -0.0000003173) / 3600
+	z := polynomial(t, -2.650545, 2306.077181, 1.0927348, 0.01826837,
+		-0.000028596, -0.0000002904) / 3600
+	theta := polynomial(t, 0, 2004.191903, -0.4294934, -0.04182264,
+		-0.000007089, -0.0000001274) / 3600
+	return rotationZ(-z).multiply(rotationY(theta)).multiply(rotationZ(-zeta))
+}
+
+// rotationY provides the matrix rotating the axes of a frame about its y
+// axis by an angle (in degrees)
+func rotationY(a float64) matrix {
+	return matrix{{cos(a), 0, -sin(a)}, {0, 1, 0}, {sin(a), 0, cos(a)}}
+}
+
+// rotationZ provides the matrix rotating the axes of a frame about its z
+// axis by an angle (in degrees)
+func rotationZ(a float64) matrix {
+	return matrix{{cos(a), sin(a), 0}, {-sin(a), cos(a), 0}, {0, 0, 1}}
+}
+
+func (m matrix) multiply(n matrix) matrix {
+	var r matrix
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 3; k++ {
+				r[i][j] += m[i][k] * n[k][j]
+			}
+		}
+	}
+	return r
+}
+
+func (m matrix) transpose() matrix {
+	var r matrix
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			r[i][j] = m[j][i]
+		}
+	}
+	return r
+}
+
+func (m matrix) apply(v vector) vector {
+	var r vector
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			r[i] += m[i][j] * v[j]
+		}
+	}
+	return r
+}
+
+// vector provides the unit vector pointing towards Equatorial coordinates
+func (q Equatorial) vector() vector {
+	return vector{
+		cos(q.Declination) * cos(q.RightAscension),
+		cos(q.Declination) * sin(q.RightAscension),
+		sin(q.Declination),
+	}
+}
+
+func (v vector) scale(k float64) vector {
+	return vector{v[0] * k, v[1] * k, v[2] * k}
 }
 
+func (v vector) subtract(w vector) vector {
+	return vector{v[0] - w[0], v[1] - w[1], v[2] - w[2]}
+}
+
+func (v vector) dot(w vector) float64 {
+	return v[0]*w[0] + v[1]*w[1] + v[2]*w[2]
+}
+
+func (v vector) length() float64 {
+	return math.Sqrt(v.dot(v))
+}
+
+// angle provides the angle (in degrees) between two vectors
+func (v vector) angle(w vector) float64 {
+	return acos(math.Max(-1, math.Min(1, v.dot(w)/v.length()/w.length())))
+}
+
+// vector provides the unit vector pointing towards Ecliptic coordinates
+func (e Ecliptic) vector() vector {
+	return vector{
+		cos(e.Latitude) * cos(e.Longitude),
+		cos(e.Latitude) * sin(e.Longitude),
+		sin(e.Latitude),
+	}
+}
+
+// ecliptic provides the Ecliptic coordinates towards which a vector points
+func (v vector) ecliptic() Ecliptic {
+	return Ecliptic{
+		Longitude: mod360(atan2(v[1], v[0])),
+		Latitude:  atan2(v[2], math.Hypot(v[0], v[1])),
+	}
+}
+
+// equatorial provides the Equatorial coordinates towards which a vector
+// points
+func (v vector) equatorial() Equatorial {
+	return Equatorial{
+		RightAscension: mod360(atan2(v[1], v[0])),
+		Declination:    atan2(v[2], math.Hypot(v[0], v[1])),
+	}
+}
+
+// Obliquity provides the true obliquity of the ecliptic (in degrees) at the
+// supplied instant, for converting between Ecliptic and Equatorial
+// coordinates
+func Obliquity(t time.Time) float64 {
+	return gregorianTime(t).since(TT).trueObliquity()
+}
+
+// solarTransit provides the julianTime at which the Sun crosses the meridian
+// of a Location on a particular julianDay, offsetting the mean solar noon by
+// the equation of time
 func (a Location) solarTransit(j julianDay) julianTime {
-	return J2000Epoch + a.meanSolarNoon(j) +
-		julianTime(0.0053*math.Sin(a.solarMeanAnomaly(j)-
-			0.0069*sin(2*a.eclipticLongitude(j))))
+	e := a.dynamicalNoon(j).equationOfTime()
+	return j2000 + a.meanSolarNoon(j) - julianTime(e/360)
 }
 
 func (a Location) solarDeclination(j julianDay) float64 {
-	return asin(sin(a.eclipticLongitude(j)) * sin(earthAngleOfTilt))
+	return a.dynamicalNoon(j).solarDeclination()
 }
 
-func (a Location) sunriseTime(j julianDay) julianTime {
-	if t := a.solarTransit(j) - a.hourAngle(j)/360; !t.IsZero() {
+// risingTime provides the julianTime at which the centre of the Sun climbs
+// through the supplied elevation (in degrees) on a particular julianDay, or
+// 0 if it does not do so
+func (a Location) risingTime(j julianDay, e float64) julianTime {
+	if t := a.solarTransit(j) - a.hourAngleAt(j, e)/360; !t.IsZero() {
 		return t
 	}
 	return 0
 }
 
-func (a Location) sunsetTime(j julianDay) julianTime {
-	if t := a.solarTransit(j) + a.hourAngle(j)/360; !t.IsZero() {
+// settingTime provides the julianTime at which the centre of the Sun sinks
+// through the supplied elevation (in degrees) on a particular julianDay, or
+// 0 if it does not do so
+func (a Location) settingTime(j julianDay, e float64) julianTime {
+	if t := a.solarTransit(j) + a.hourAngleAt(j, e)/360; !t.IsZero() {
 		return t
 	}
 	return 0
 }
 
-// Altitude.correction attempts to provide a correcting value for solar
-// transit calculations with regards to altitude. It's probably going to be
-// quite wide of the mark for values above sea level, and it doesn't even
-// attempt to provide accurate value for locations below sea level.
-func (a Altitude) correction() float64 {
-	if a > 0 {
-		return -2.076 * (math.Sqrt(float64(a) / 60))
+// dip provides the angle (in degrees) by which the sea horizon lies below the
+// horizontal for an observer at an Altitude, allowing for the refraction of
+// light passing close to the ground. There is no sea horizon to see from at or
+// below sea level, so no dip is given there.
+func (a Altitude) dip() float64 {
+	if a <= 0 {
+		return 0
 	}
-	return -0.1625
+	return 1.76 / 60 * math.Sqrt(float64(a))
 }
 
-func (a Location) hourAngle(j julianDay) julianTime {
-	return julianTime(acos((sin(-0.83+a.Altitude.correction()) -
-		sin(a.Latitude)*sin(a.solarDeclination(j))) /
-		cos(a.Latitude) / cos(a.solarDeclination(j))))
+// pressure provides the typical air pressure (in millibars) at an Altitude,
+// in the International Standard Atmosphere scaled to StandardWeather at sea
+// level. The formula reaches zero at about 44 km, above which it is 0.
+func (a Altitude) pressure() float64 {
+	return StandardWeather.Pressure *
+		math.Pow(math.Max(1-2.25577e-5*float64(a), 0), 5.25588)
 }
 
-func (g gregorianTime) fractionalDay() float64 {
-	return (g.hour()*3600 + g.minute()*60 + g.second()) / 86400
+// weather provides the Weather set by options, or else the typical Weather
+// at the Altitude of a Location
+func (a Location) weather(o options) Weather {
+	if o.weather != nil {
+		return *o.weather
+	}
+	return Weather{StandardWeather.Temperature, a.Altitude.pressure()}
+}
+
+// horizonElevation is the elevation of the centre of the Sun at the moment of
+// sunrise or sunset, when its upper limb touches the horizon, allowing for
+// the horizon's dip at the Location's Altitude and refraction in the
+// supplied Weather
+func (a Location) horizonElevation(w Weather) float64 {
+	d := a.Altitude.dip()
+	return -d - RefractionFromApparent(-d, w) - solarSemidiameter
+}
+
+// horizonCrossing provides the julianTime at which the upper limb of the Sun
+// first appears over (if rising is true) or last disappears behind a Horizon
+// on a particular julianDay, allowing for refraction in the supplied Weather,
+// or 0 if it does not do so
+func (a Location) horizonCrossing(j julianDay, h Horizon, w Weather,
+	rising bool) julianTime {
+	const step = julianTime(2.0 / 1440)
+	n, dir := a.solarTransit(j), julianTime(1)
+	if !rising {
+		dir = -1
+	}
+	d0 := a.skylineOffset(n-dir/2, h, w)
+	for t := n - dir/2; dir*(n-t) > 0; t += dir * step {
+		d1 := a.skylineOffset(t+dir*step, h, w)
+		if d0 < 0 && d1 >= 0 {
+			return a.skylineCrossing(t, t+dir*step, h, w)
+		}
+		d0 = d1
+	}
+	return 0
+}
+
+// skylineCrossing narrows down the julianTime at which the upper limb of the
+// Sun passes a Horizon, given julianTimes either side of it
+func (a Location) skylineCrossing(lo, hi julianTime, h Horizon,
+	w Weather) julianTime {
+	const precision = 0.5 / 86400
+	below := a.skylineOffset(lo, h, w) < 0
+	for math.Abs(float64(hi-lo)) > precision {
+		if m := (lo + hi) / 2; (a.skylineOffset(m, h, w) < 0) == below {
+			lo = m
+		} else {
+			hi = m
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// skylineOffset provides the angle (in degrees) by which the upper limb of
+// the Sun appears above a Horizon at a julianTime, allowing for refraction in
+// the supplied Weather
+func (a Location) skylineOffset(j julianTime, h Horizon, w Weather) float64 {
+	p := a.sunPosition(j)
+	return p.Elevation + RefractionFromTrue(p.Elevation, w) +
+		solarSemidiameter - h.Elevation(p.Azimuth)
+}
+
+// Elevation provides the elevation (in degrees) of a Horizon at the supplied
+// azimuth, interpolating between the HorizonPoints either side of it. An
+// empty Horizon is level, at an elevation of 0.
+func (h Horizon) Elevation(azimuth float64) float64 {
+	if len(h) == 0 {
+		return 0
+	}
+	before, after := 0, 0
+	for i := range h {
+		if mod360(azimuth-h[i].Azimuth) < mod360(azimuth-h[before].Azimuth) {
+			before = i
+		}
+		if mod360(h[i].Azimuth-azimuth) < mod360(h[after].Azimuth-azimuth) {
+			after = i
+		}
+	}
+	db := mod360(azimuth - h[before].Azimuth)
+	da := mod360(h[after].Azimuth - azimuth)
+	if db+da == 0 {
+		return h[before].Elevation
+	}
+	return h[before].Elevation +
+		(h[after].Elevation-h[before].Elevation)*db/(db+da)
+}
+
+// WithHorizon is an Option that takes sunrise and sunset to be when the Sun
+// appears over and disappears behind the supplied Horizon, rather than the
+// sea horizon
+func WithHorizon(h Horizon) Option {
+	return func(o *options) {
+		o.horizon = h
+	}
+}
+
+// WithWeather is an Option that allows for refraction in the supplied
+// Weather
+func WithWeather(w Weather) Option {
+	return func(o *options) {
+		o.weather = &w
+	}
+}
+
+// newOptions provides the options set by a list of Options
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// RefractionFromApparent provides the amount (in degrees) by which refraction
+// in the supplied Weather raises an object seen at the supplied apparent
+// elevation (in degrees), using the formula of Bennett. Elevations below -1°
+// are treated as -1°, where the formula still holds.
+func RefractionFromApparent(elevation float64, w Weather) float64 {
+	h := math.Max(elevation, -1)
+	return math.Max(cot(h+7.31/(h+4.4)), 0) / 60 * w.factor()
+}
+
+// RefractionFromTrue provides the amount (in degrees) by which refraction in
+// the supplied Weather raises an object whose true elevation (in degrees) is
+// supplied, using the formula of Saemundsson. Elevations below -1° are
+// treated as -1°, where the formula still holds.
+func RefractionFromTrue(elevation float64, w Weather) float64 {
+	h := math.Max(elevation, -1)
+	return math.Max(1.02*cot(h+10.3/(h+5.11)), 0) / 60 * w.factor()
+}
+
+// factor provides the amount by which refraction in a Weather differs from
+// that in StandardWeather
+func (w Weather) factor() float64 {
+	return w.Pressure / StandardWeather.Pressure *
+		(273 + StandardWeather.Temperature) / (273 + w.Temperature)
+}
+
+// hourAngleAt provides the hour angle (in degrees) of the Sun when its centre
+// is at the supplied elevation on a particular julianDay
+func (a Location) hourAngleAt(j julianDay, e float64) julianTime {
+	return julianTime(acos(a.cosHourAngleAt(j, e)))
 }
 
-func (g gregorianTime) year() float64 {
-	return float64(time.Time(g).Year())
+// cosHourAngleAt provides the cosine of the hour angle of the Sun when its
+// centre is at the supplied elevation on a particular julianDay. Values
+// outside of [-1, 1] mean that the Sun does not reach that elevation.
+func (a Location) cosHourAngleAt(j julianDay, e float64) float64 {
+	return (sin(e) - sin(a.Latitude)*sin(a.solarDeclination(j))) /
+		cos(a.Latitude) / cos(a.solarDeclination(j))
 }
 
-func (g gregorianTime) month() float64 {
-	return float64(time.Time(g).Month())
+// polarState provides ErrPolarDay if the Sun stays above the supplied
+// elevation for the whole of a julianDay, ErrPolarNight if it stays below it,
+// or nil if it passes through it
+func (a Location) polarState(j julianDay, e float64) error {
+	switch c := a.cosHourAngleAt(j, e); {
+	case c < -1:
+		return ErrPolarDay
+	case c > 1:
+		return ErrPolarNight
+	}
+	return nil
 }
 
-func (g gregorianTime) day() float64 {
-	return float64(time.Time(g).Day())
+func (g gregorianTime) fractionalDay() float64 {
+	return (g.hour()*3600 + g.minute()*60 + g.second() +
+		float64(time.Time(g).Nanosecond())/1e9) / 86400
 }
 
 func (g gregorianTime) hour() float64 {
@@ -187,6 +5035,11 @@ func cos(a float64) float64 {
 	return math.Cos(a / 180 * math.Pi)
 }
 
+// cot provides the Cotangent of an angle that is provided in degress
+func cot(a float64) float64 {
+	return 1 / math.Tan(a/180*math.Pi)
+}
+
 // asin provides the arcsine in degress of the supplied value
 func asin(a float64) float64 {
 	return math.Asin(a) * 180 / math.Pi
@@ -197,6 +5050,20 @@ func acos(a float64) float64 {
 	return math.Acos(a) * 180 / math.Pi
 }
 
+// atan2 provides the arctangent in degress of y/x, using the signs of both to
+// determine the quadrant
+func atan2(y, x float64) float64 {
+	return math.Atan2(y, x) * 180 / math.Pi
+}
+
+// mod360 normalises an angle in degrees into the range [0, 360)
+func mod360(a float64) float64 {
+	if a = math.Mod(a, 360); a < 0 {
+		return a + 360
+	}
+	return a
+}
+
 func (a Location) validate() error {
 	return validator.Validate(a)
 }
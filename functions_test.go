@@ -2,6 +2,8 @@
 package astro
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"testing"
@@ -101,9 +103,9 @@ var TestJulianTimeJ2000EpochData = []struct {
 	input  julianTime
 	output julianTime
 }{
-	{input: 24583346.324461, output: 22131801.325261},
-	{input: 23437892.876532, output: 20986347.877332},
-	{input: 29999999.999999, output: 27548455.000799},
+	{input: 24583346.324461, output: 22131801.324461},
+	{input: 23437892.876532, output: 20986347.876532},
+	{input: 29999999.999999, output: 27548454.999999},
 }
 
 func TestJulianTimeJ2000Epoch(t *testing.T) {
@@ -130,13 +132,13 @@ var TestLocationMeanSolarNoonData = []struct {
 		TestLocationMeanSolarNoonInput{
 			Location{0, 0, 0}, 2453954,
 		},
-		2409.000800,
+		2409.000000,
 	},
 	{
 		TestLocationMeanSolarNoonInput{
 			Location{51.5, -0.12462, 0}, 2464546,
 		},
-		13001.000454,
+		13001.000346,
 	},
 }
 
@@ -162,15 +164,15 @@ var TestLocationSolarMeanAnomalyData = []struct {
 }{
 	{
 		TestLocationSolarMeanAnomalyInput{
-			Location{0, 0, 0}, 23437892.000000,
+			Location{0, 0, 0}, 2460483,
 		},
-		347.009266,
+		166.825192,
 	},
 	{
 		TestLocationSolarMeanAnomalyInput{
-			Location{32, -120, 0}, 23437892.000000,
+			Location{32, -120, 0}, 2460483,
 		},
-		346.680732,
+		167.153725,
 	},
 }
 
@@ -196,15 +198,15 @@ var TestLocationEquationOfTheCentreData = []struct {
 }{
 	{
 		TestLocationEquationOfTheCentreInput{
-			Location{0, 0, 0}, 23437892.000000,
+			Location{0, 0, 0}, 2460483,
 		},
-		0.005126,
+		0.427434,
 	},
 	{
 		TestLocationEquationOfTheCentreInput{
 			Location{-43.1415, 112.23626, 0}, 2454192.000000,
 		},
-		-1.464470,
+		1.912260,
 	},
 }
 
@@ -230,15 +232,15 @@ var TestLocationEclipticLongitudeData = []struct {
 }{
 	{
 		TestLocationEclipticLongitudeInput{
-			Location{0, 0, 0}, 0,
+			Location{0, 0, 0}, 2451545,
 		},
-		-2.936267,
+		280.373346,
 	},
 	{
 		TestLocationEclipticLongitudeInput{
-			Location{34.2, 11.2, 0}, 22131859,
+			Location{34.2, 11.2, 0}, 2460483,
 		},
-		41.662002,
+		90.574423,
 	},
 }
 
@@ -264,15 +266,15 @@ var TestLocationSolarTransitData = []struct {
 }{
 	{
 		LocationSolarTransitInput{
-			Location{0, 0, 0}, 12345678,
+			Location{0, 0, 0}, 2460483,
 		},
-		12345677.995510,
+		2460483.001338,
 	},
 	{
 		LocationSolarTransitInput{
 			Location{34.219, 11.462, 0}, 2454449,
 		},
-		2454449.034946,
+		2454448.964327,
 	},
 }
 
@@ -309,72 +311,6 @@ func TestGregorianTimeFractionalDay(t *testing.T) {
 	}
 }
 
-var TestGregorianTimeYearData = []struct {
-	input  gregorianTime
-	output float64
-}{
-	{
-		input: gregorianTime(time.Date(2007, 12, 14, 21, 7, 51, 0,
-			time.FixedZone("PDT", -25200))),
-		output: 2007,
-	},
-}
-
-func TestGregorianTimeYear(t *testing.T) {
-	data := TestGregorianTimeYearData
-	for i := 0; i < len(data); i++ {
-		input, output := data[i].input, data[i].output
-		if result := input.year(); result != output {
-			t.Errorf("expected result %f, got result %f", output,
-				result)
-		}
-	}
-}
-
-var TestGregorianTimeMonthData = []struct {
-	input  gregorianTime
-	output float64
-}{
-	{
-		input: gregorianTime(time.Date(2007, 12, 14, 21, 7, 51, 0,
-			time.FixedZone("PDT", -25200))),
-		output: 12,
-	},
-}
-
-func TestGregorianTimeMonth(t *testing.T) {
-	data := TestGregorianTimeMonthData
-	for i := 0; i < len(data); i++ {
-		input, output := data[i].input, data[i].output
-		if result := input.month(); result != output {
-			t.Errorf("expected result %f, got result %f", output,
-				result)
-		}
-	}
-}
-
-var TestGregorianTimeDayData = []struct {
-	input  gregorianTime
-	output float64
-}{
-	{
-		input: gregorianTime(time.Date(2007, 12, 14, 21, 7, 51, 0,
-			time.FixedZone("PDT", -25200))),
-		output: 14,
-	},
-}
-
-func TestGregorianTimeDay(t *testing.T) {
-	data := TestGregorianTimeDayData
-	for i := 0; i < len(data); i++ {
-		input, output := data[i].input, data[i].output
-		if result := input.day(); result != output {
-			t.Errorf("expected result %f, got result %f", output,
-				result)
-		}
-	}
-}
-
 var TestGregorianTimeHourData = []struct {
 	input  gregorianTime
 	output float64
@@ -509,15 +445,15 @@ var TestLocationSolarDeclinationData = []struct {
 }{
 	{
 		LocationSolarDeclinationInput{
-			Location{0, 0, 0}, 12345678,
+			Location{0, 0, 0}, 2460483,
 		},
-		-23.117070,
+		23.437019,
 	},
 	{
 		LocationSolarDeclinationInput{
 			Location{-134.219, 11.462, 0}, 2454449,
 		},
-		-23.135386,
+		-23.203250,
 	},
 }
 
@@ -559,7 +495,7 @@ var TestGregorianTimeJulianData = []struct {
 	{
 		gregorianTime(time.Date(2017, 12, 14, 21, 7, 51, 0,
 			time.FixedZone("PDT", -25200))),
-		2458102.380451,
+		2458102.672118,
 	},
 }
 
@@ -573,65 +509,33 @@ func TestGregorianTimeJulian(t *testing.T) {
 	}
 }
 
-var TestGregorianTimeC19CorrectionData = []struct {
-	input  gregorianTime
-	output float64
-}{
-	{
-		gregorianTime(time.Date(2007, 12, 14, 21, 7, 51, 0,
-			time.FixedZone("PDT", -25200))),
-		0.000000,
-	},
-	{
-		gregorianTime(time.Date(1900, 2, 28, 21, 7, 51, 0,
-			time.FixedZone("UTC", 0))),
-		1.000000,
-	},
-	{
-		gregorianTime(time.Date(1900, 3, 1, 21, 7, 51, 0,
-			time.FixedZone("UTC", 0))),
-		0.000000,
-	},
-}
-
-func TestGregorianTimeC19Correction(t *testing.T) {
-	data := TestGregorianTimeC19CorrectionData
-	for i := 0; i < len(data); i++ {
-		input, output := data[i].input, data[i].output
-		result := input.c19Correction()
-		if !almostEqual(result, output) {
-			t.Errorf("expected: `%f`; got: `%f`", output, result)
-		}
-	}
-}
-
 var TestJulianTimeGregorianData = []struct {
 	input  julianTime
 	output gregorianTime
 }{
 	{
 		julianTime(2460528.38793),
-		gregorianTime(time.Date(2024, 8, 5, 21, 18, 37, 0,
+		gregorianTime(time.Date(2024, 8, 5, 21, 18, 37, 152017000,
 			time.FixedZone("UTC", 0))),
 	},
 	{
 		julianTime(2460527.596272),
-		gregorianTime(time.Date(2024, 8, 5, 2, 18, 37, 0,
+		gregorianTime(time.Date(2024, 8, 5, 2, 18, 37, 900810000,
 			time.FixedZone("UTC", 0))),
 	},
 	{
 		julianTime(2460619.97127),
-		gregorianTime(time.Date(2024, 11, 5, 11, 18, 37, 0,
+		gregorianTime(time.Date(2024, 11, 5, 11, 18, 37, 728008000,
 			time.FixedZone("UTC", 0))),
 	},
 	{
 		julianTime(2451545.13125),
-		gregorianTime(time.Date(2000, 1, 1, 15, 9, 0, 0,
+		gregorianTime(time.Date(2000, 1, 1, 15, 9, 0, 8000,
 			time.FixedZone("UTC", 0))),
 	},
 	{
 		julianTime(2445853.03403),
-		gregorianTime(time.Date(1984, 6, 1, 12, 49, 0, 0,
+		gregorianTime(time.Date(1984, 6, 1, 12, 49, 0, 192018000,
 			time.FixedZone("UTC", 0))),
 	},
 }
@@ -647,59 +551,31 @@ func TestJulianTimeGregorian(t *testing.T) {
 	}
 }
 
-var TestAltitudeCorrectionData = []struct {
-	input  Altitude
-	output float64
+var TestAltitudeDipData = []struct {
+	input    Altitude
+	dip      float64
+	pressure float64
 }{
-	{input: 0, output: -0.162500},
-	{input: 10, output: -0.847523},
-	{input: 50, output: -1.895120},
-	{input: 100, output: -2.680104},
-	{input: 314.159265359, output: -4.750361},
-	{input: 1000, output: -8.475235},
+	{input: 0, dip: 0, pressure: 1010},
+	{input: -430, dip: 0, pressure: 1062.565},
+	{input: 10, dip: 0.092760, pressure: 1008.803},
+	{input: 100, dip: 0.293333, pressure: 998.083},
+	{input: 1000, dip: 0.927601, pressure: 895.863},
+	{input: 50000, dip: 6.559133, pressure: 0},
 }
 
-func TestAltitudeCorrection(t *testing.T) {
-	data := TestAltitudeCorrectionData
+func TestAltitudeDip(t *testing.T) {
+	data := TestAltitudeDipData
 	for i := 0; i < len(data); i++ {
-		input, output := data[i].input, data[i].output
-		if result := input.correction(); !almostEqual(result, output) {
-			t.Errorf("expected result %f, got result %f", output,
+		input := data[i].input
+		if result := input.dip(); !almostEqual(result, data[i].dip) {
+			t.Errorf("expected result %f, got result %f", data[i].dip,
 				result)
 		}
-	}
-}
-
-type TestLocationHourAngleInput struct {
-	location Location
-	day      julianDay
-}
-
-var TestLocationHourAngleData = []struct {
-	input  TestLocationHourAngleInput
-	output julianTime
-}{
-	{
-		TestLocationHourAngleInput{
-			Location{0, 0, 0}, 12345678,
-		},
-		91.079161,
-	},
-	{
-		TestLocationHourAngleInput{
-			Location{-134.219, 11.462, 0}, 2454449,
-		},
-		62.219506,
-	},
-}
-
-func TestLocationHourAngle(t *testing.T) {
-	data := TestLocationHourAngleData
-	for i := 0; i < len(data); i++ {
-		input, output := data[i].input, data[i].output
-		result := input.location.hourAngle(input.day)
-		if !result.almostEqual(output) {
-			t.Errorf("expected result %f, got result %f", output, result)
+		if result := input.pressure(); math.Abs(result-data[i].pressure) >
+			0.001 {
+			t.Errorf("expected result %f, got result %f", data[i].pressure,
+				result)
 		}
 	}
 }
@@ -716,7 +592,7 @@ var TestGregorianTimeJulianDateData = []struct {
 	{
 		gregorianTime(time.Date(2039, 1, 12, 1, 7, 51, 0,
 			time.FixedZone("GMT", 0))),
-		2465800.000000,
+		2465801.000000,
 	},
 }
 
@@ -740,7 +616,7 @@ var TestJulianTimeIsZeroData = []struct {
 		true,
 	},
 	{
-		julianTime(J2000Epoch),
+		julianTime(2451545),
 		false,
 	},
 	{
@@ -785,7 +661,7 @@ func TestGregorianTimeJulianDay(t *testing.T) {
 	data := TestGregorianTimeJulianDayData
 	for i := 0; i < len(data); i++ {
 		input, output := data[i].input, data[i].output
-		if result := input.julian().julianDay(); result != output {
+		if result := input.julianDay(); result != output {
 			t.Errorf("expected result %f, got result %f", output,
 				result)
 		}
@@ -837,67 +713,2479 @@ func TestGregorianTimeString(t *testing.T) {
 	}
 }
 
-type sunTimeDataInputs struct {
+type TestLocationSunTimesInput struct {
 	location Location
-	day      julianDay
+	date     time.Time
 }
 
-var TestLocationSunriseTimeData = []struct {
-	input  sunTimeDataInputs
-	output julianTime
+var TestLocationSunTimesData = []struct {
+	input  TestLocationSunTimesInput
+	output SunTimes
+	err    error
+}{
+	{
+		TestLocationSunTimesInput{
+			Location{31.5, 35.5, -430},
+			time.Date(2024, 6, 21, 9, 0, 0, 0,
+				time.FixedZone("IDT", 10800)),
+		},
+		SunTimes{
+			Sunrise: time.Date(2024, 6, 21, 5, 33, 39, 0,
+				time.FixedZone("IDT", 10800)),
+			SolarNoon: time.Date(2024, 6, 21, 12, 39, 54, 0,
+				time.FixedZone("IDT", 10800)),
+			Sunset: time.Date(2024, 6, 21, 19, 46, 10, 0,
+				time.FixedZone("IDT", 10800)),
+		},
+		nil,
+	},
+	{
+		TestLocationSunTimesInput{
+			Location{51.5, -0.12, 0},
+			time.Date(2024, 6, 21, 9, 0, 0, 0,
+				time.FixedZone("BST", 3600)),
+		},
+		SunTimes{
+			Sunrise: time.Date(2024, 6, 21, 4, 43, 13, 0,
+				time.FixedZone("BST", 3600)),
+			SolarNoon: time.Date(2024, 6, 21, 13, 2, 24, 0,
+				time.FixedZone("BST", 3600)),
+			Sunset: time.Date(2024, 6, 21, 21, 21, 36, 0,
+				time.FixedZone("BST", 3600)),
+		},
+		nil,
+	},
+	{
+		TestLocationSunTimesInput{
+			Location{-33.9, 151.2, 0},
+			time.Date(2024, 6, 21, 23, 0, 0, 0,
+				time.FixedZone("AEST", 36000)),
+		},
+		SunTimes{
+			Sunrise: time.Date(2024, 6, 21, 7, 0, 11, 0,
+				time.FixedZone("AEST", 36000)),
+			SolarNoon: time.Date(2024, 6, 21, 11, 57, 2, 0,
+				time.FixedZone("AEST", 36000)),
+			Sunset: time.Date(2024, 6, 21, 16, 53, 54, 0,
+				time.FixedZone("AEST", 36000)),
+		},
+		nil,
+	},
+	{
+		TestLocationSunTimesInput{
+			Location{78.2, 15.6, 0},
+			time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC),
+		},
+		SunTimes{
+			SolarNoon: time.Date(2024, 6, 21, 10, 59, 31, 0, time.UTC),
+		},
+		ErrPolarDay,
+	},
+	{
+		TestLocationSunTimesInput{
+			Location{78.2, 15.6, 0},
+			time.Date(2024, 12, 21, 12, 0, 0, 0, time.UTC),
+		},
+		SunTimes{
+			SolarNoon: time.Date(2024, 12, 21, 10, 55, 53, 0, time.UTC),
+		},
+		ErrPolarNight,
+	},
+	{
+		TestLocationSunTimesInput{
+			Location{45, 10, 0},
+			time.Date(2132, 8, 31, 12, 0, 0, 0, time.UTC),
+		},
+		SunTimes{
+			Sunrise:   time.Date(2132, 8, 31, 4, 41, 31, 0, time.UTC),
+			SolarNoon: time.Date(2132, 8, 31, 11, 20, 22, 0, time.UTC),
+			Sunset:    time.Date(2132, 8, 31, 17, 59, 13, 0, time.UTC),
+		},
+		nil,
+	},
+	{
+		TestLocationSunTimesInput{
+			Location{-60, 35, 0},
+			time.Date(2018, 10, 5, 12, 0, 0, 0, time.UTC),
+		},
+		SunTimes{
+			Sunrise:   time.Date(2018, 10, 5, 2, 48, 17, 0, time.UTC),
+			SolarNoon: time.Date(2018, 10, 5, 9, 28, 27, 0, time.UTC),
+			Sunset:    time.Date(2018, 10, 5, 16, 8, 38, 0, time.UTC),
+		},
+		nil,
+	},
+	{
+		TestLocationSunTimesInput{
+			Location{45, -90, 0},
+			time.Date(2084, 10, 1, 12, 0, 0, 0, time.UTC),
+		},
+		SunTimes{
+			Sunrise:   time.Date(2084, 10, 1, 11, 59, 38, 0, time.UTC),
+			SolarNoon: time.Date(2084, 10, 1, 17, 49, 17, 0, time.UTC),
+			Sunset:    time.Date(2084, 10, 1, 23, 38, 57, 0, time.UTC),
+		},
+		nil,
+	},
+	{
+		TestLocationSunTimesInput{
+			Location{45, 0, 50000},
+			time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC),
+		},
+		SunTimes{
+			Sunrise:   time.Date(2024, 3, 20, 5, 28, 1, 0, time.UTC),
+			SolarNoon: time.Date(2024, 3, 20, 12, 7, 19, 0, time.UTC),
+			Sunset:    time.Date(2024, 3, 20, 18, 46, 37, 0, time.UTC),
+		},
+		nil,
+	},
+	{
+		TestLocationSunTimesInput{
+			Location{95, 0, 0},
+			time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC),
+		},
+		SunTimes{},
+		fmt.Errorf("Latitude: greater than max"),
+	},
+}
+
+func TestLocationSunTimes(t *testing.T) {
+	data := TestLocationSunTimesData
+	for i := 0; i < len(data); i++ {
+		input, output, out := data[i].input, data[i].output, data[i].err
+		result, err := input.location.SunTimes(input.date)
+		if err != nil && out != nil && err.Error() != out.Error() ||
+			err != nil && out == nil || err == nil && out != nil {
+			t.Errorf("expected `%s`; got: `%s`", out, err)
+		}
+		if !result.Sunrise.Equal(output.Sunrise) ||
+			!result.SolarNoon.Equal(output.SolarNoon) ||
+			!result.Sunset.Equal(output.Sunset) {
+			t.Errorf("expected: `%v`; got: `%v`", output, result)
+		}
+	}
+}
+
+type TestLocationSunTimesWeatherInput struct {
+	location Location
+	date     time.Time
+	weather  Weather
+}
+
+var TestLocationSunTimesWeatherData = []struct {
+	input  TestLocationSunTimesWeatherInput
+	output SunTimes
 }{
 	{
-		sunTimeDataInputs{Location{45, 10, 0}, 2500000.5},
-		julianTime(2500000.251258),
+		TestLocationSunTimesWeatherInput{
+			Location{69.65, 18.96, 0},
+			time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			StandardWeather,
+		},
+		SunTimes{
+			Sunrise:   time.Date(2024, 3, 1, 6, 7, 1, 0, time.UTC),
+			SolarNoon: time.Date(2024, 3, 1, 10, 56, 23, 0, time.UTC),
+			Sunset:    time.Date(2024, 3, 1, 15, 45, 46, 0, time.UTC),
+		},
 	},
 	{
-		sunTimeDataInputs{Location{-60, 35, 0}, 2458397.5},
-		julianTime(2458397.3214121),
+		TestLocationSunTimesWeatherInput{
+			Location{69.65, 18.96, 0},
+			time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			Weather{Temperature: -30, Pressure: 1030},
+		},
+		SunTimes{
+			Sunrise:   time.Date(2024, 3, 1, 6, 5, 42, 0, time.UTC),
+			SolarNoon: time.Date(2024, 3, 1, 10, 56, 23, 0, time.UTC),
+			Sunset:    time.Date(2024, 3, 1, 15, 47, 4, 0, time.UTC),
+		},
 	},
 	{
-		sunTimeDataInputs{Location{45, -90, 0}, 2482500.5},
-		julianTime(2482500.006067),
+		TestLocationSunTimesWeatherInput{
+			Location{69.65, 18.96, 0},
+			time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			Weather{Temperature: 30, Pressure: 750},
+		},
+		SunTimes{
+			Sunrise:   time.Date(2024, 3, 1, 6, 9, 9, 0, time.UTC),
+			SolarNoon: time.Date(2024, 3, 1, 10, 56, 23, 0, time.UTC),
+			Sunset:    time.Date(2024, 3, 1, 15, 43, 37, 0, time.UTC),
+		},
 	},
 }
 
-func TestLocationSunriseTime(t *testing.T) {
-	data := TestLocationSunriseTimeData
+func TestLocationSunTimesWeather(t *testing.T) {
+	data := TestLocationSunTimesWeatherData
 	for i := 0; i < len(data); i++ {
 		input, output := data[i].input, data[i].output
-		result := input.location.sunriseTime(input.day)
-		if output.gregorian() != result.gregorian() {
-			t.Errorf("expected: `%s`; got: `%s`", result.gregorian(),
-				output.gregorian())
+		result, err := input.location.SunTimes(input.date,
+			WithWeather(input.weather))
+		if err != nil {
+			t.Errorf("expected: `%v`; got: `%s`", nil, err)
+		}
+		if !result.Sunrise.Equal(output.Sunrise) ||
+			!result.SolarNoon.Equal(output.SolarNoon) ||
+			!result.Sunset.Equal(output.Sunset) {
+			t.Errorf("expected: `%v`; got: `%v`", output, result)
 		}
 	}
 }
 
-var TestLocationSunsetTimeData = []struct {
-	input  sunTimeDataInputs
-	output julianTime
+var TestHorizonElevationData = []struct {
+	input  float64
+	output float64
+}{
+	{0, 2.8},
+	{45, 6.4},
+	{90, 10},
+	{135, 15},
+	{300, 3.875},
+	{355, 2.4},
+	{10, 3.6},
+}
+
+func TestHorizonElevation(t *testing.T) {
+	h := Horizon{{180, 20}, {90, 10}, {350, 2}, {270, 5}}
+	data := TestHorizonElevationData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		if result := h.Elevation(input); !almostEqual(result, output) {
+			t.Errorf("expected: `%f`; got: `%f`", output, result)
+		}
+	}
+	if result := (Horizon{}).Elevation(123); result != 0 {
+		t.Errorf("expected: `%f`; got: `%f`", 0.0, result)
+	}
+}
+
+type TestLocationSunTimesHorizonInput struct {
+	location Location
+	date     time.Time
+	horizon  Horizon
+}
+
+var TestLocationSunTimesHorizonData = []struct {
+	input  TestLocationSunTimesHorizonInput
+	output SunTimes
+	err    error
+}{
+	{
+		TestLocationSunTimesHorizonInput{
+			Location{46.62, 8.03, 1034},
+			time.Date(2024, 12, 21, 0, 0, 0, 0, time.FixedZone("CET", 3600)),
+			Horizon{{90, 12}, {135, 18}, {180, 15}, {225, 16}, {270, 8}},
+		},
+		SunTimes{
+			Sunrise: time.Date(2024, 12, 21, 10, 44, 53, 0,
+				time.FixedZone("CET", 3600)),
+			SolarNoon: time.Date(2024, 12, 21, 12, 26, 11, 0,
+				time.FixedZone("CET", 3600)),
+			Sunset: time.Date(2024, 12, 21, 14, 21, 3, 0,
+				time.FixedZone("CET", 3600)),
+		},
+		nil,
+	},
+	{
+		TestLocationSunTimesHorizonInput{
+			Location{46.62, 8.03, 1034},
+			time.Date(2024, 12, 21, 0, 0, 0, 0, time.FixedZone("CET", 3600)),
+			Horizon{},
+		},
+		SunTimes{
+			Sunrise: time.Date(2024, 12, 21, 8, 9, 35, 0,
+				time.FixedZone("CET", 3600)),
+			SolarNoon: time.Date(2024, 12, 21, 12, 26, 11, 0,
+				time.FixedZone("CET", 3600)),
+			Sunset: time.Date(2024, 12, 21, 16, 42, 47, 0,
+				time.FixedZone("CET", 3600)),
+		},
+		nil,
+	},
+	{
+		TestLocationSunTimesHorizonInput{
+			Location{46.62, 8.03, 1034},
+			time.Date(2024, 12, 21, 0, 0, 0, 0, time.FixedZone("CET", 3600)),
+			Horizon{{0, 25}},
+		},
+		SunTimes{
+			SolarNoon: time.Date(2024, 12, 21, 12, 26, 11, 0,
+				time.FixedZone("CET", 3600)),
+		},
+		ErrPolarNight,
+	},
+}
+
+func TestLocationSunTimesHorizon(t *testing.T) {
+	data := TestLocationSunTimesHorizonData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		result, err := input.location.SunTimes(input.date,
+			WithHorizon(input.horizon))
+		if err != data[i].err {
+			t.Errorf("expected: `%v`; got: `%v`", data[i].err, err)
+		}
+		if !result.Sunrise.Equal(output.Sunrise) ||
+			!result.SolarNoon.Equal(output.SolarNoon) ||
+			!result.Sunset.Equal(output.Sunset) {
+			t.Errorf("expected: `%v`; got: `%v`", output, result)
+		}
+	}
+}
+
+var TestLocationMoonTimesData = []struct {
+	location Location
+	date     time.Time
+	output   MoonTimes
+}{
+	{
+		Location{51.5, -0.12, 0},
+		time.Date(2024, 6, 21, 12, 0, 0, 0, time.FixedZone("BST", 3600)),
+		MoonTimes{
+			Rise: []time.Time{time.Date(2024, 6, 21, 21, 43, 19, 0,
+				time.FixedZone("BST", 3600))},
+			Set: []time.Time{time.Date(2024, 6, 21, 3, 25, 2, 0,
+				time.FixedZone("BST", 3600))},
+			Transit: []time.Time{time.Date(2024, 6, 21, 0, 1, 24, 0,
+				time.FixedZone("BST", 3600))},
+		},
+	},
+	{
+		Location{51.5, -0.12, 0},
+		time.Date(2024, 6, 25, 12, 0, 0, 0, time.FixedZone("BST", 3600)),
+		MoonTimes{
+			Set: []time.Time{time.Date(2024, 6, 25, 8, 17, 23, 0,
+				time.FixedZone("BST", 3600))},
+			Transit: []time.Time{time.Date(2024, 6, 25, 3, 56, 18, 0,
+				time.FixedZone("BST", 3600))},
+		},
+	},
+	{
+		Location{51.5, -0.12, 0},
+		time.Date(2024, 6, 20, 12, 0, 0, 0, time.FixedZone("BST", 3600)),
+		MoonTimes{
+			Rise: []time.Time{time.Date(2024, 6, 20, 20, 33, 8, 0,
+				time.FixedZone("BST", 3600))},
+			Set: []time.Time{time.Date(2024, 6, 20, 2, 49, 59, 0,
+				time.FixedZone("BST", 3600))},
+		},
+	},
+	{
+		Location{69.65, 18.96, 0},
+		time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC),
+		MoonTimes{
+			Transit: []time.Time{
+				time.Date(2024, 6, 21, 22, 40, 31, 0, time.UTC),
+			},
+			AlwaysDown: true,
+		},
+	},
+	{
+		Location{69.65, 18.96, 0},
+		time.Date(2024, 6, 8, 12, 0, 0, 0, time.UTC),
+		MoonTimes{
+			Transit: []time.Time{
+				time.Date(2024, 6, 8, 12, 34, 41, 0, time.UTC),
+			},
+			AlwaysUp: true,
+		},
+	},
+}
+
+func TestLocationMoonTimes(t *testing.T) {
+	data := TestLocationMoonTimesData
+	for i := 0; i < len(data); i++ {
+		output := data[i].output
+		result, err := data[i].location.MoonTimes(data[i].date)
+		if err != nil {
+			t.Errorf("expected: `%v`; got: `%v`", nil, err)
+		}
+		if !equalTimes(result.Rise, output.Rise) ||
+			!equalTimes(result.Set, output.Set) ||
+			!equalTimes(result.Transit, output.Transit) ||
+			result.AlwaysUp != output.AlwaysUp ||
+			result.AlwaysDown != output.AlwaysDown {
+			t.Errorf("expected: `%v`; got: `%v`", output, result)
+		}
+	}
+}
+
+func TestMoonTimesMarshalJSON(t *testing.T) {
+	m := MoonTimes{
+		Set: []time.Time{
+			time.Date(2024, 6, 25, 8, 17, 23, 0, time.FixedZone("BST", 3600)),
+		},
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := `{"rise":[],"set":["2024-06-25T08:17:23+01:00"],"transit":[],` +
+		`"alwaysUp":false,"alwaysDown":false}`
+	if string(b) != output {
+		t.Errorf("expected: `%s`; got: `%s`", output, b)
+	}
+}
+
+func equalTimes(a, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+var (
+	sirius  = Star{"Sirius", Equatorial{101.287155, -16.716116}}
+	polaris = Star{"Polaris", Equatorial{37.954561, 89.264109}}
+	canopus = Star{"Canopus", Equatorial{95.987958, -52.695661}}
+)
+
+type TestLocationRiseTransitSetInput struct {
+	body             Body
+	standardAltitude float64
+}
+
+var TestLocationRiseTransitSetData = []struct {
+	input  TestLocationRiseTransitSetInput
+	output BodyTimes
+	err    error
 }{
 	{
-		sunTimeDataInputs{Location{45, 10, 0}, 2500000.5},
-		julianTime(2500000.809059),
+		TestLocationRiseTransitSetInput{Sun, StandardAltitudeSun},
+		BodyTimes{
+			Rise: time.Date(2024, 6, 21, 4, 43, 13, 0,
+				time.FixedZone("BST", 3600)),
+			Transit: time.Date(2024, 6, 21, 13, 2, 24, 0,
+				time.FixedZone("BST", 3600)),
+			Set: time.Date(2024, 6, 21, 21, 21, 34, 0,
+				time.FixedZone("BST", 3600)),
+		},
+		nil,
+	},
+	{
+		TestLocationRiseTransitSetInput{Moon, StandardAltitudeMoon},
+		BodyTimes{
+			Rise: time.Date(2024, 6, 21, 21, 43, 54, 0,
+				time.FixedZone("BST", 3600)),
+			Transit: time.Date(2024, 6, 21, 0, 1, 24, 0,
+				time.FixedZone("BST", 3600)),
+			Set: time.Date(2024, 6, 21, 3, 24, 31, 0,
+				time.FixedZone("BST", 3600)),
+		},
+		nil,
+	},
+	{
+		TestLocationRiseTransitSetInput{Mars, StandardAltitudeStar},
+		BodyTimes{
+			Rise: time.Date(2024, 6, 21, 2, 14, 33, 0,
+				time.FixedZone("BST", 3600)),
+			Transit: time.Date(2024, 6, 21, 9, 28, 1, 0,
+				time.FixedZone("BST", 3600)),
+			Set: time.Date(2024, 6, 21, 16, 42, 18, 0,
+				time.FixedZone("BST", 3600)),
+		},
+		nil,
+	},
+	{
+		TestLocationRiseTransitSetInput{sirius, StandardAltitudeStar},
+		BodyTimes{
+			Rise: time.Date(2024, 6, 21, 9, 11, 24, 0,
+				time.FixedZone("BST", 3600)),
+			Transit: time.Date(2024, 6, 21, 13, 45, 52, 0,
+				time.FixedZone("BST", 3600)),
+			Set: time.Date(2024, 6, 21, 18, 20, 20, 0,
+				time.FixedZone("BST", 3600)),
+		},
+		nil,
+	},
+	{
+		TestLocationRiseTransitSetInput{polaris, StandardAltitudeStar},
+		BodyTimes{
+			Transit: time.Date(2024, 6, 21, 10, 1, 41, 0,
+				time.FixedZone("BST", 3600)),
+		},
+		ErrCircumpolar,
+	},
+	{
+		TestLocationRiseTransitSetInput{canopus, StandardAltitudeStar},
+		BodyTimes{
+			Transit: time.Date(2024, 6, 21, 13, 24, 10, 0,
+				time.FixedZone("BST", 3600)),
+		},
+		ErrNeverRises,
 	},
 	{
-		sunTimeDataInputs{Location{-60, 35, 0}, 2458397.5},
-		julianTime(2458397.884761),
+		TestLocationRiseTransitSetInput{Planet(42), StandardAltitudeStar},
+		BodyTimes{},
+		ErrUnknownBody,
 	},
 	{
-		sunTimeDataInputs{Location{45, -90, 0}, 2482500.5},
-		julianTime(2482500.495580),
+		TestLocationRiseTransitSetInput{Luminary(2), StandardAltitudeSun},
+		BodyTimes{},
+		ErrUnknownBody,
 	},
 }
 
-func TestLocationSunsetTime(t *testing.T) {
-	data := TestLocationSunsetTimeData
+func TestLocationRiseTransitSet(t *testing.T) {
+	l := Location{51.5, -0.12, 0}
+	date := time.Date(2024, 6, 21, 12, 0, 0, 0, time.FixedZone("BST", 3600))
+	data := TestLocationRiseTransitSetData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		result, err := l.RiseTransitSet(input.body, date,
+			input.standardAltitude)
+		if err != data[i].err {
+			t.Errorf("expected: `%v`; got: `%v`", data[i].err, err)
+		}
+		if !result.Rise.Equal(output.Rise) ||
+			!result.Transit.Equal(output.Transit) ||
+			!result.Set.Equal(output.Set) {
+			t.Errorf("expected: `%v`; got: `%v`", output, result)
+		}
+	}
+}
+
+func TestBodyTimesMarshalJSON(t *testing.T) {
+	input := BodyTimes{
+		Transit: time.Date(2024, 6, 21, 10, 1, 41, 0,
+			time.FixedZone("BST", 3600)),
+	}
+	output := `{"rise":"n/a","transit":"2024-06-21T10:01:41+01:00",` +
+		`"set":"n/a"}`
+	result, err := input.MarshalJSON()
+	if err != nil || string(result) != output {
+		t.Errorf("expected: `%s`; got: `%s`", output, result)
+	}
+}
+
+var TestMoonPhaseData = []struct {
+	input  time.Time
+	output LunarPhase
+}{
+	{
+		time.Date(1992, 4, 12, 0, 0, 0, 0, time.UTC),
+		LunarPhase{69.066374410, 0.678643101, true, FirstQuarter,
+			8.790609451, 285.046331619, 0},
+	},
+	{
+		time.Date(2024, 6, 6, 12, 37, 0, 0, time.UTC),
+		LunarPhase{175.492328251, 0.001546590, false, NewMoon,
+			29.385172315, 173.801891483, 0},
+	},
+	{
+		time.Date(2024, 6, 28, 21, 53, 0, 0, time.UTC),
+		LunarPhase{89.853160195, 0.501281418, false, LastQuarter,
+			22.385424094, 66.755481327, 0},
+	},
+}
+
+func TestMoonPhase(t *testing.T) {
+	data := TestMoonPhaseData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		if result := MoonPhase(input); !equalLunarPhase(result, output) {
+			t.Errorf("expected: `%v`; got: `%v`", output, result)
+		}
+	}
+}
+
+func TestLocationMoonPhase(t *testing.T) {
+	l := Location{51.5, -0.12, 0}
+	tm := time.Date(2024, 6, 21, 22, 0, 0, 0, time.UTC)
+	output := LunarPhase{5.938760521, 0.997316525, true, FullMoon,
+		15.390285205, 193.282373221, 211.003936256}
+	if result := l.MoonPhase(tm); !equalLunarPhase(result, output) {
+		t.Errorf("expected: `%v`; got: `%v`", output, result)
+	}
+}
+
+var TestPrincipalPhaseData = []struct {
+	input  float64
+	phase  PhaseName
+	output julianTime
+}{
+	{-283, NewMoon, 2443192.651182684},
+	{-282.75, FirstQuarter, 2443200.618699906},
+	{544.5, FullMoon, 2467629.286347765},
+	{544.75, LastQuarter, 2467636.491863892},
+}
+
+func TestPrincipalPhase(t *testing.T) {
+	data := TestPrincipalPhaseData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		phase, result := principalPhase(input)
+		if phase != data[i].phase || !almostEqual(float64(result),
+			float64(output)) {
+			t.Errorf("expected: `%v %f`; got: `%v %f`", data[i].phase, output,
+				phase, result)
+		}
+	}
+}
+
+func TestMoonPhases(t *testing.T) {
+	from := time.Date(2024, 6, 6, 12, 37, 41, 0, time.UTC)
+	to := time.Date(2024, 7, 28, 2, 51, 32, 0, time.UTC)
+	output := []PhaseTime{
+		{NewMoon, time.Date(2024, 6, 6, 12, 37, 41, 0, time.UTC)},
+		{FirstQuarter, time.Date(2024, 6, 14, 5, 18, 27, 0, time.UTC)},
+		{FullMoon, time.Date(2024, 6, 22, 1, 7, 52, 0, time.UTC)},
+		{LastQuarter, time.Date(2024, 6, 28, 21, 53, 25, 0, time.UTC)},
+		{NewMoon, time.Date(2024, 7, 5, 22, 57, 22, 0, time.UTC)},
+		{FirstQuarter, time.Date(2024, 7, 13, 22, 48, 43, 0, time.UTC)},
+		{FullMoon, time.Date(2024, 7, 21, 10, 17, 7, 0, time.UTC)},
+	}
+	var result []PhaseTime
+	for p := range MoonPhases(from, to) {
+		result = append(result, p)
+	}
+	if len(result) != len(output) {
+		t.Fatalf("expected: `%v`; got: `%v`", output, result)
+	}
+	for i := range output {
+		if result[i].Phase != output[i].Phase ||
+			!result[i].Time.Equal(output[i].Time) {
+			t.Errorf("expected: `%v`; got: `%v`", output[i], result[i])
+		}
+	}
+	n := 0
+	for range MoonPhases(from, to) {
+		if n++; n == 2 {
+			break
+		}
+	}
+	if n != 2 {
+		t.Errorf("expected: `%d`; got: `%d`", 2, n)
+	}
+}
+
+func TestPhaseTimeMarshalJSON(t *testing.T) {
+	input := PhaseTime{
+		FullMoon,
+		time.Date(2024, 6, 22, 2, 7, 52, 0, time.FixedZone("BST", 3600)),
+	}
+	output := `{"phase":"Full Moon","time":"2024-06-22T02:07:52+01:00"}`
+	result, err := input.MarshalJSON()
+	if err != nil || string(result) != output {
+		t.Errorf("expected: `%s`; got: `%s`", output, result)
+	}
+}
+
+func equalLunarPhase(a, b LunarPhase) bool {
+	return almostEqual(a.Angle, b.Angle) &&
+		almostEqual(a.Illumination, b.Illumination) &&
+		a.Waxing == b.Waxing && a.Name == b.Name &&
+		almostEqual(a.Age, b.Age) &&
+		almostEqual(a.BrightLimb, b.BrightLimb) &&
+		almostEqual(a.ZenithBrightLimb, b.ZenithBrightLimb)
+}
+
+var TestRefractionData = []struct {
+	input    float64
+	weather  Weather
+	apparent float64
+	true     float64
+}{
+	{-5, StandardWeather, 0.830262, 0.646581},
+	{0, StandardWeather, 0.574626, 0.483032},
+	{10, StandardWeather, 0.089858, 0.090128},
+	{45, StandardWeather, 0.016581, 0.016878},
+	{90, StandardWeather, 0, 0},
+	{0, Weather{Temperature: -30, Pressure: 1030}, 0.682466, 0.573683},
+}
+
+func TestRefraction(t *testing.T) {
+	data := TestRefractionData
+	for i := 0; i < len(data); i++ {
+		input, w := data[i].input, data[i].weather
+		if result := RefractionFromApparent(input, w); !almostEqual(result,
+			data[i].apparent) {
+			t.Errorf("expected: `%f`; got: `%f`", data[i].apparent, result)
+		}
+		if result := RefractionFromTrue(input, w); !almostEqual(result,
+			data[i].true) {
+			t.Errorf("expected: `%f`; got: `%f`", data[i].true, result)
+		}
+	}
+}
+
+var TestSunTimesMarshalJSONData = []struct {
+	input  SunTimes
+	output string
+}{
+	{
+		SunTimes{
+			Sunrise: time.Date(2024, 6, 21, 4, 42, 56, 0,
+				time.FixedZone("BST", 3600)),
+			SolarNoon: time.Date(2024, 6, 21, 13, 3, 27, 0,
+				time.FixedZone("BST", 3600)),
+			Sunset: time.Date(2024, 6, 21, 21, 23, 57, 0,
+				time.FixedZone("BST", 3600)),
+		},
+		`{"sunrise":"2024-06-21T04:42:56+01:00",` +
+			`"solarNoon":"2024-06-21T13:03:27+01:00",` +
+			`"sunset":"2024-06-21T21:23:57+01:00"}`,
+	},
+	{
+		SunTimes{},
+		`{"sunrise":"n/a","solarNoon":"n/a","sunset":"n/a"}`,
+	},
+}
+
+func TestSunTimesMarshalJSON(t *testing.T) {
+	data := TestSunTimesMarshalJSONData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		result, err := input.MarshalJSON()
+		if err != nil || string(result) != output {
+			t.Errorf("expected: `%s`; got: `%s`", output, result)
+		}
+	}
+}
+
+type TestLocationHourAngleAtInput struct {
+	location  Location
+	day       julianDay
+	elevation float64
+}
+
+var TestLocationHourAngleAtData = []struct {
+	input  TestLocationHourAngleAtInput
+	output julianTime
+}{
+	{
+		TestLocationHourAngleAtInput{
+			Location{51.5, -0.12, 0}, 2460483, -6,
+		},
+		136.719305,
+	},
+	{
+		TestLocationHourAngleAtInput{
+			Location{51.5, -0.12, 0}, 2460483, -18,
+		},
+		julianTime(math.NaN()),
+	},
+}
+
+func TestLocationHourAngleAt(t *testing.T) {
+	data := TestLocationHourAngleAtData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		result := input.location.hourAngleAt(input.day, input.elevation)
+		if !result.almostEqual(output) &&
+			!(result.IsZero() && output.IsZero()) {
+			t.Errorf("expected result %f, got result %f", output, result)
+		}
+	}
+}
+
+var TestLocationTwilightData = []struct {
+	input  TestLocationSunTimesInput
+	output Twilight
+}{
+	{
+		TestLocationSunTimesInput{
+			Location{51.5, -0.12, 0},
+			time.Date(2024, 12, 21, 12, 0, 0, 0, time.UTC),
+		},
+		Twilight{
+			Civil: DawnDusk{
+				Dawn: time.Date(2024, 12, 21, 7, 23, 41, 0, time.UTC),
+				Dusk: time.Date(2024, 12, 21, 16, 33, 54, 0, time.UTC),
+			},
+			Nautical: DawnDusk{
+				Dawn: time.Date(2024, 12, 21, 6, 40, 30, 0, time.UTC),
+				Dusk: time.Date(2024, 12, 21, 17, 17, 5, 0, time.UTC),
+			},
+			Astronomical: DawnDusk{
+				Dawn: time.Date(2024, 12, 21, 5, 59, 42, 0, time.UTC),
+				Dusk: time.Date(2024, 12, 21, 17, 57, 53, 0, time.UTC),
+			},
+			MorningGoldenHour: Interval{
+				time.Date(2024, 12, 21, 7, 38, 52, 0, time.UTC),
+				time.Date(2024, 12, 21, 9, 5, 41, 0, time.UTC),
+			},
+			EveningGoldenHour: Interval{
+				time.Date(2024, 12, 21, 14, 51, 54, 0, time.UTC),
+				time.Date(2024, 12, 21, 16, 18, 43, 0, time.UTC),
+			},
+			MorningBlueHour: Interval{
+				time.Date(2024, 12, 21, 7, 23, 41, 0, time.UTC),
+				time.Date(2024, 12, 21, 7, 38, 52, 0, time.UTC),
+			},
+			EveningBlueHour: Interval{
+				time.Date(2024, 12, 21, 16, 18, 43, 0, time.UTC),
+				time.Date(2024, 12, 21, 16, 33, 54, 0, time.UTC),
+			},
+		},
+	},
+	{
+		TestLocationSunTimesInput{
+			Location{51.5, -0.12, 0},
+			time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC),
+		},
+		Twilight{
+			Civil: DawnDusk{
+				Dawn: time.Date(2024, 6, 21, 2, 55, 32, 0, time.UTC),
+				Dusk: time.Date(2024, 6, 21, 21, 9, 17, 0, time.UTC),
+			},
+			Nautical: DawnDusk{
+				Dawn: time.Date(2024, 6, 21, 1, 40, 56, 0, time.UTC),
+				Dusk: time.Date(2024, 6, 21, 22, 23, 53, 0, time.UTC),
+			},
+			Astronomical: DawnDusk{AlwaysAbove: true},
+			MorningGoldenHour: Interval{
+				time.Date(2024, 6, 21, 3, 15, 1, 0, time.UTC),
+				time.Date(2024, 6, 21, 4, 37, 31, 0, time.UTC),
+			},
+			EveningGoldenHour: Interval{
+				time.Date(2024, 6, 21, 19, 27, 18, 0, time.UTC),
+				time.Date(2024, 6, 21, 20, 49, 47, 0, time.UTC),
+			},
+			MorningBlueHour: Interval{
+				time.Date(2024, 6, 21, 2, 55, 32, 0, time.UTC),
+				time.Date(2024, 6, 21, 3, 15, 1, 0, time.UTC),
+			},
+			EveningBlueHour: Interval{
+				time.Date(2024, 6, 21, 20, 49, 47, 0, time.UTC),
+				time.Date(2024, 6, 21, 21, 9, 17, 0, time.UTC),
+			},
+		},
+	},
+	{
+		TestLocationSunTimesInput{
+			Location{78.2, 15.6, 0},
+			time.Date(2024, 12, 21, 12, 0, 0, 0, time.UTC),
+		},
+		Twilight{
+			Civil: DawnDusk{AlwaysBelow: true},
+			Nautical: DawnDusk{
+				Dawn: time.Date(2024, 12, 21, 9, 56, 55, 0, time.UTC),
+				Dusk: time.Date(2024, 12, 21, 11, 54, 52, 0, time.UTC),
+			},
+			Astronomical: DawnDusk{
+				Dawn: time.Date(2024, 12, 21, 6, 37, 18, 0, time.UTC),
+				Dusk: time.Date(2024, 12, 21, 15, 14, 28, 0, time.UTC),
+			},
+		},
+	},
+}
+
+func TestLocationTwilight(t *testing.T) {
+	data := TestLocationTwilightData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		result, err := input.location.Twilight(input.date)
+		if err != nil {
+			t.Errorf("unexpected error: `%s`", err)
+		}
+		for _, r := range [][2]time.Time{
+			{result.Civil.Dawn, output.Civil.Dawn},
+			{result.Civil.Dusk, output.Civil.Dusk},
+			{result.Nautical.Dawn, output.Nautical.Dawn},
+			{result.Nautical.Dusk, output.Nautical.Dusk},
+			{result.Astronomical.Dawn, output.Astronomical.Dawn},
+			{result.Astronomical.Dusk, output.Astronomical.Dusk},
+			{result.MorningGoldenHour.Start, output.MorningGoldenHour.Start},
+			{result.MorningGoldenHour.End, output.MorningGoldenHour.End},
+			{result.EveningGoldenHour.Start, output.EveningGoldenHour.Start},
+			{result.EveningGoldenHour.End, output.EveningGoldenHour.End},
+			{result.MorningBlueHour.Start, output.MorningBlueHour.Start},
+			{result.MorningBlueHour.End, output.MorningBlueHour.End},
+			{result.EveningBlueHour.Start, output.EveningBlueHour.Start},
+			{result.EveningBlueHour.End, output.EveningBlueHour.End},
+		} {
+			if !r[0].Equal(r[1]) {
+				t.Errorf("expected: `%s`; got: `%s`",
+					gregorianTime(r[1]), gregorianTime(r[0]))
+			}
+		}
+		for _, r := range [][2]DawnDusk{
+			{result.Civil, output.Civil},
+			{result.Nautical, output.Nautical},
+			{result.Astronomical, output.Astronomical},
+		} {
+			if r[0].AlwaysAbove != r[1].AlwaysAbove ||
+				r[0].AlwaysBelow != r[1].AlwaysBelow {
+				t.Errorf("expected: `%v`; got: `%v`", r[1], r[0])
+			}
+		}
+	}
+}
+
+type TestLocationPolarStateInput struct {
+	location  Location
+	day       julianDay
+	elevation float64
+}
+
+var TestLocationPolarStateData = []struct {
+	input  TestLocationPolarStateInput
+	output error
+}{
+	{
+		TestLocationPolarStateInput{
+			Location{78.2, 15.6, 0}, 2460483, -0.83,
+		},
+		ErrPolarDay,
+	},
+	{
+		TestLocationPolarStateInput{
+			Location{78.2, 15.6, 0}, 2460666, -0.83,
+		},
+		ErrPolarNight,
+	},
+	{
+		TestLocationPolarStateInput{
+			Location{51.5, -0.12, 0}, 2460483, -0.83,
+		},
+		nil,
+	},
+	{
+		TestLocationPolarStateInput{
+			Location{51.5, -0.12, 0}, 2460483, -18,
+		},
+		ErrPolarDay,
+	},
+}
+
+func TestLocationPolarState(t *testing.T) {
+	data := TestLocationPolarStateData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		result := input.location.polarState(input.day, input.elevation)
+		if result != output {
+			t.Errorf("expected: `%s`; got: `%s`", output, result)
+		}
+	}
+}
+
+var TestATan2Data = []struct {
+	input  [2]float64
+	output float64
+}{
+	{input: [2]float64{0, 1}, output: 0},
+	{input: [2]float64{1, 1}, output: 45},
+	{input: [2]float64{1, 0}, output: 90},
+	{input: [2]float64{-1, -1}, output: -135},
+}
+
+func TestATan2(t *testing.T) {
+	data := TestATan2Data
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		if result := atan2(input[0], input[1]); !almostEqual(result, output) {
+			t.Errorf("expected result %f, got result %f", output,
+				result)
+		}
+	}
+}
+
+var TestMod360Data = []struct {
+	input  float64
+	output float64
+}{
+	{input: 0, output: 0},
+	{input: 360, output: 0},
+	{input: 725.5, output: 5.5},
+	{input: -90, output: 270},
+	{input: -1080.25, output: 359.75},
+}
+
+func TestMod360(t *testing.T) {
+	data := TestMod360Data
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		if result := mod360(input); !almostEqual(result, output) {
+			t.Errorf("expected result %f, got result %f", output,
+				result)
+		}
+	}
+}
+
+var TestJulianTimeMeanSiderealTimeData = []struct {
+	input  julianTime
+	output float64
+}{
+	{input: -4649.5, output: 197.693195},
+	{input: 0, output: 280.460618},
+}
+
+func TestJulianTimeMeanSiderealTime(t *testing.T) {
+	data := TestJulianTimeMeanSiderealTimeData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		if result := input.meanSiderealTime(); !almostEqual(result, output) {
+			t.Errorf("expected: `%f`; got: `%f`", output, result)
+		}
+	}
+}
+
+var TestJulianTimeMeanObliquityData = []struct {
+	input  julianTime
+	output float64
+}{
+	{input: 0, output: 23.439291},
+	{input: -4647.5, output: 23.440946},
+}
+
+func TestJulianTimeMeanObliquity(t *testing.T) {
+	data := TestJulianTimeMeanObliquityData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		if result := input.meanObliquity(); !almostEqual(result, output) {
+			t.Errorf("expected: `%f`; got: `%f`", output, result)
+		}
+	}
+}
+
+var TestJulianTimeNutationData = []struct {
+	input  julianTime
+	output [2]float64
+}{
+	{input: -4649.5, output: [2]float64{-3.787931, 9.442520}},
+	{input: 0, output: [2]float64{-13.923153, -5.773910}},
+}
+
+func TestJulianTimeNutation(t *testing.T) {
+	data := TestJulianTimeNutationData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		psi, eps := input.nutation()
+		if !almostEqual(psi*3600, output[0]) ||
+			!almostEqual(eps*3600, output[1]) {
+			t.Errorf("expected: `%v`; got: `%v`", output,
+				[2]float64{psi * 3600, eps * 3600})
+		}
+	}
+}
+
+var TestGreenwichSiderealTimeData = []struct {
+	input  time.Time
+	output [2]time.Duration
+}{
+	{
+		time.Date(1987, 4, 10, 0, 0, 0, 0, time.UTC),
+		[2]time.Duration{
+			13*time.Hour + 10*time.Minute + 46367*time.Millisecond,
+			13*time.Hour + 10*time.Minute + 46135*time.Millisecond,
+		},
+	},
+	{
+		time.Date(1987, 4, 10, 21, 21, 0, 0, time.FixedZone("CEST", 7200)),
+		[2]time.Duration{
+			8*time.Hour + 34*time.Minute + 57090*time.Millisecond,
+			8*time.Hour + 34*time.Minute + 56853*time.Millisecond,
+		},
+	},
+}
+
+func TestGreenwichSiderealTime(t *testing.T) {
+	data := TestGreenwichSiderealTimeData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		result := [2]time.Duration{
+			GreenwichMeanSiderealTime(input).Round(time.Millisecond),
+			GreenwichApparentSiderealTime(input).Round(time.Millisecond),
+		}
+		if result != output {
+			t.Errorf("expected: `%s`; got: `%s`", output, result)
+		}
+	}
+}
+
+type TestLocationLocalSiderealTimeInput struct {
+	location Location
+	time     time.Time
+}
+
+var TestLocationLocalSiderealTimeData = []struct {
+	input  TestLocationLocalSiderealTimeInput
+	output time.Duration
+}{
+	{
+		TestLocationLocalSiderealTimeInput{
+			Location{51.5, -0.12, 0},
+			time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC),
+		},
+		6*time.Hour + 13*time.Second,
+	},
+	{
+		TestLocationLocalSiderealTimeInput{
+			Location{-33.9, 151.2, 0},
+			time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC),
+		},
+		16*time.Hour + 5*time.Minute + 30*time.Second,
+	},
+}
+
+func TestLocationLocalSiderealTime(t *testing.T) {
+	data := TestLocationLocalSiderealTimeData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		result := input.location.LocalSiderealTime(input.time).
+			Round(time.Second)
+		if result != output {
+			t.Errorf("expected: `%s`; got: `%s`", output, result)
+		}
+	}
+}
+
+var TestEquatorialEclipticData = []struct {
+	input     Equatorial
+	obliquity float64
+	output    Ecliptic
+}{
+	{Equatorial{116.328942, 28.026183}, 23.4392911,
+		Ecliptic{113.215630, 6.684170}},
+	{Equatorial{0, 0}, 23.4392911, Ecliptic{0, 0}},
+	{Equatorial{270, -23.4392911}, 23.4392911, Ecliptic{270, 0}},
+}
+
+func TestEquatorialEcliptic(t *testing.T) {
+	data := TestEquatorialEclipticData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		result := input.Ecliptic(data[i].obliquity)
+		if !almostEqual(result.Longitude, output.Longitude) ||
+			!almostEqual(result.Latitude, output.Latitude) {
+			t.Errorf("expected: `%v`; got: `%v`", output, result)
+		}
+		back := result.Equatorial(data[i].obliquity)
+		if !almostEqual(back.RightAscension, input.RightAscension) ||
+			!almostEqual(back.Declination, input.Declination) {
+			t.Errorf("expected: `%v`; got: `%v`", input, back)
+		}
+	}
+}
+
+var TestEquatorialGalacticData = []struct {
+	input  Equatorial
+	output Galactic
+}{
+	{Equatorial{192.85948, 27.12825}, Galactic{122.93192, 90}},
+	{Equatorial{266.40499, -28.93617}, Galactic{0.000001, 0.000006}},
+	{Equatorial{0, 0}, Galactic{96.337272, -60.188553}},
+}
+
+func TestEquatorialGalactic(t *testing.T) {
+	data := TestEquatorialGalacticData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		result := input.Galactic()
+		if !almostEqual(result.Longitude, output.Longitude) ||
+			!almostEqual(result.Latitude, output.Latitude) {
+			t.Errorf("expected: `%v`; got: `%v`", output, result)
+		}
+		back := result.Equatorial()
+		if !almostEqual(back.RightAscension, input.RightAscension) ||
+			!almostEqual(back.Declination, input.Declination) {
+			t.Errorf("expected: `%v`; got: `%v`", input, back)
+		}
+	}
+}
+
+type TestLocationHorizontalInput struct {
+	location   Location
+	time       time.Time
+	equatorial Equatorial
+}
+
+var TestLocationHorizontalData = []struct {
+	input  TestLocationHorizontalInput
+	output Horizontal
+}{
+	{
+		TestLocationHorizontalInput{
+			Location{38.921389, -77.065556, 0},
+			time.Date(1987, 4, 10, 19, 21, 0, 0, time.UTC),
+			Equatorial{347.3193375, -6.719892},
+		},
+		Horizontal{248.033595, 15.124974, 74.875026},
+	},
+	{
+		TestLocationHorizontalInput{
+			Location{51.5, -0.12, 0},
+			time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC),
+			Equatorial{37.95, 89.26},
+		},
+		Horizontal{359.052502, 51.950724, 38.049276},
+	},
+}
+
+func TestLocationHorizontal(t *testing.T) {
+	data := TestLocationHorizontalData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		result := input.location.Horizontal(input.equatorial, input.time)
+		if !almostEqual(result.Azimuth, output.Azimuth) ||
+			!almostEqual(result.Elevation, output.Elevation) ||
+			!almostEqual(result.Zenith, output.Zenith) {
+			t.Errorf("expected: `%v`; got: `%v`", output, result)
+		}
+		back := input.location.Equatorial(result, input.time)
+		if !almostEqual(back.RightAscension,
+			input.equatorial.RightAscension) ||
+			!almostEqual(back.Declination, input.equatorial.Declination) {
+			t.Errorf("expected: `%v`; got: `%v`", input.equatorial, back)
+		}
+	}
+}
+
+type TestPrecessInput struct {
+	coord    Equatorial
+	from, to JulianDate
+}
+
+var TestPrecessData = []struct {
+	input  TestPrecessInput
+	output Equatorial
+}{
+	{
+		TestPrecessInput{
+			Equatorial{41.054063, 49.227750},
+			J2000Epoch(), NewJulianDate(2462088.69, 0),
+		},
+		Equatorial{41.547187, 49.348475},
+	},
+	{
+		TestPrecessInput{
+			Equatorial{10.68471, 41.26875}, J2000Epoch(),
+			NewJulianDate(2488070, 0),
+		},
+		Equatorial{12.063524, 41.814407},
+	},
+	{
+		TestPrecessInput{
+			Equatorial{37.95454, 89.26411}, J2000Epoch(), J2000Epoch(),
+		},
+		Equatorial{37.95454, 89.26411},
+	},
+}
+
+func TestPrecess(t *testing.T) {
+	data := TestPrecessData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		result := Precess(input.coord, input.from, input.to)
+		if !almostEqual(result.RightAscension, output.RightAscension) ||
+			!almostEqual(result.Declination, output.Declination) {
+			t.Errorf("expected: `%v`; got: `%v`", output, result)
+		}
+		back := Precess(result, input.to, input.from)
+		if !almostEqual(back.RightAscension, input.coord.RightAscension) ||
+			!almostEqual(back.Declination, input.coord.Declination) {
+			t.Errorf("expected: `%v`; got: `%v`", input.coord, back)
+		}
+	}
+}
+
+var TestObliquityData = []struct {
+	input  time.Time
+	output float64
+}{
+	{time.Date(1987, 4, 10, 0, 0, 0, 0, time.UTC), 23.443569},
+	{time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC), 23.437687},
+}
+
+func TestObliquity(t *testing.T) {
+	data := TestObliquityData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		if result := Obliquity(input); !almostEqual(result, output) {
+			t.Errorf("expected: `%f`; got: `%f`", output, result)
+		}
+	}
+}
+
+func TestJulianTimeSunEcliptic(t *testing.T) {
+	// Meeus, Astronomical Algorithms, example 25.b
+	j := julianTime(2448908.5) - j2000
+	output := Ecliptic{199.906060, 0.000172}
+	result, distance := j.sunEcliptic()
+	if math.Abs(result.Longitude-output.Longitude) > 1e-6 ||
+		math.Abs(result.Latitude-output.Latitude) > 1e-6 {
+		t.Errorf("expected: `%v`; got: `%v`", output, result)
+	}
+	if au := distance / astronomicalUnit; math.Abs(au-0.99760775) > 1e-8 {
+		t.Errorf("expected: `%v`; got: `%v`", 0.99760775, au)
+	}
+}
+
+var TestSeasonsData = []struct {
+	input  int
+	output SeasonTimes
+}{
+	{
+		2024,
+		SeasonTimes{
+			time.Date(2024, 3, 20, 3, 6, 28, 0, time.UTC),
+			time.Date(2024, 6, 20, 20, 50, 58, 0, time.UTC),
+			time.Date(2024, 9, 22, 12, 43, 41, 0, time.UTC),
+			time.Date(2024, 12, 21, 9, 20, 29, 0, time.UTC),
+		},
+	},
+	{
+		1962,
+		SeasonTimes{
+			time.Date(1962, 3, 21, 2, 29, 33, 0, time.UTC),
+			time.Date(1962, 6, 21, 21, 24, 6, 0, time.UTC),
+			time.Date(1962, 9, 23, 12, 35, 16, 0, time.UTC),
+			time.Date(1962, 12, 22, 8, 15, 15, 0, time.UTC),
+		},
+	},
+	{
+		3000,
+		SeasonTimes{
+			time.Date(3000, 3, 20, 16, 14, 18, 0, time.UTC),
+			time.Date(3000, 6, 20, 15, 40, 21, 0, time.UTC),
+			time.Date(3000, 9, 22, 13, 35, 23, 0, time.UTC),
+			time.Date(3000, 12, 22, 4, 3, 19, 0, time.UTC),
+		},
+	},
+}
+
+func TestSeasons(t *testing.T) {
+	data := TestSeasonsData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		result := Seasons(input)
+		if !result.MarchEquinox.Equal(output.MarchEquinox) ||
+			!result.JuneSolstice.Equal(output.JuneSolstice) ||
+			!result.SeptemberEquinox.Equal(output.SeptemberEquinox) ||
+			!result.DecemberSolstice.Equal(output.DecemberSolstice) {
+			t.Errorf("expected: `%v`; got: `%v`", output, result)
+		}
+	}
+}
+
+func TestSeasonTimesMarshalJSON(t *testing.T) {
+	output := `{"marchEquinox":"2024-03-20T03:06:28+00:00",` +
+		`"juneSolstice":"2024-06-20T20:50:58+00:00",` +
+		`"septemberEquinox":"2024-09-22T12:43:41+00:00",` +
+		`"decemberSolstice":"2024-12-21T09:20:29+00:00"}`
+	result, err := Seasons(2024).MarshalJSON()
+	if err != nil || string(result) != output {
+		t.Errorf("expected: `%s`; got: `%s`", output, result)
+	}
+}
+
+type TestLocationSunPositionInput struct {
+	location Location
+	time     time.Time
+}
+
+var TestLocationSunPositionData = []struct {
+	input  TestLocationSunPositionInput
+	output Horizontal
+}{
+	{
+		TestLocationSunPositionInput{
+			Location{51.5, -0.12, 0},
+			time.Date(2024, 6, 21, 12, 2, 11, 0, time.UTC),
+		},
+		Horizontal{179.889280, 61.936978, 28.063022},
+	},
+	{
+		TestLocationSunPositionInput{
+			Location{51.5, -0.12, 0},
+			time.Date(2024, 6, 21, 10, 0, 0, 0,
+				time.FixedZone("CEST", 7200)),
+		},
+		Horizontal{97.480672, 36.279237, 53.720763},
+	},
+	{
+		TestLocationSunPositionInput{
+			Location{-33.9, 151.2, 0},
+			time.Date(2024, 6, 21, 2, 0, 0, 0, time.UTC),
+		},
+		Horizontal{359.193412, 32.657435, 57.342565},
+	},
+}
+
+func TestLocationSunPosition(t *testing.T) {
+	data := TestLocationSunPositionData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		result := input.location.SunPosition(input.time)
+		if !almostEqual(result.Azimuth, output.Azimuth) ||
+			!almostEqual(result.Elevation, output.Elevation) ||
+			!almostEqual(result.Zenith, output.Zenith) {
+			t.Errorf("expected: `%v`; got: `%v`", output, result)
+		}
+	}
+}
+
+var TestLocationSunPositionWeatherData = []struct {
+	input  time.Time
+	output Horizontal
+}{
+	{
+		time.Date(2024, 6, 21, 20, 0, 0, 0, time.UTC),
+		Horizontal{306.879869, 2.076193, 87.923807},
+	},
+	{
+		time.Date(2024, 6, 21, 20, 30, 0, 0, time.UTC),
+		Horizontal{312.752421, -1.805962, 91.805962},
+	},
+	{
+		time.Date(2024, 6, 21, 23, 30, 0, 0, time.UTC),
+		Horizontal{352.291235, -14.725647, 104.725647},
+	},
+}
+
+func TestLocationSunPositionWeather(t *testing.T) {
+	l := Location{51.5, -0.12, 0}
+	data := TestLocationSunPositionWeatherData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		result := l.SunPosition(input, WithWeather(StandardWeather))
+		if !almostEqual(result.Azimuth, output.Azimuth) ||
+			!almostEqual(result.Elevation, output.Elevation) ||
+			!almostEqual(result.Zenith, output.Zenith) {
+			t.Errorf("expected: `%v`; got: `%v`", output, result)
+		}
+	}
+}
+
+func TestJulianTimeMoonEcliptic(t *testing.T) {
+	// Meeus, Astronomical Algorithms, example 47.a
+	j := julianTime(2448724.5) - j2000
+	output := Ecliptic{133.167264281, -3.229126419}
+	result, distance := j.moonEcliptic()
+	if !almostEqual(result.Longitude, output.Longitude) ||
+		!almostEqual(result.Latitude, output.Latitude) {
+		t.Errorf("expected: `%v`; got: `%v`", output, result)
+	}
+	if math.Abs(distance-368409.7) > 0.1 {
+		t.Errorf("expected: `%v`; got: `%v`", 368409.7, distance)
+	}
+}
+
+type TestLocationMoonPositionInput struct {
+	location Location
+	time     time.Time
+	opts     []Option
+}
+
+var TestLocationMoonPositionData = []struct {
+	input  TestLocationMoonPositionInput
+	output Coords
+}{
+	{
+		TestLocationMoonPositionInput{
+			Location{51.5, -0.12, 0},
+			time.Date(2024, 6, 21, 22, 0, 0, 0, time.UTC), nil,
+		},
+		Coords{
+			Ecliptic{269.651500680, -5.796919195},
+			Equatorial{269.602672772, -29.234837452},
+			379896.278215,
+			Horizontal{154.742418930, 5.294080425, 84.705919575},
+		},
+	},
+	{
+		TestLocationMoonPositionInput{
+			Location{-33.9, 18.4, 1000},
+			time.Date(2024, 6, 21, 22, 0, 0, 0, time.UTC),
+			[]Option{WithWeather(StandardWeather)},
+		},
+		Coords{
+			Ecliptic{269.501122692, -4.790659925},
+			Equatorial{269.435759649, -28.228081750},
+			374287.510507,
+			Horizontal{60.425153141, 79.415950035, 10.584049965},
+		},
+	},
+	{
+		TestLocationMoonPositionInput{
+			Location{51.5, -0.12, 0},
+			time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC),
+			[]Option{WithWeather(StandardWeather)},
+		},
+		Coords{
+			Ecliptic{263.835172378, -5.126059892},
+			Equatorial{263.014921791, -28.415106826},
+			388092.155377,
+			Horizontal{15.570592648, -66.319013979, 156.319013979},
+		},
+	},
+}
+
+func TestMoonPosition(t *testing.T) {
+	tm := time.Date(1992, 4, 12, 0, 0, 0, 0, time.UTC)
+	output := Coords{
+		Ecliptic:   Ecliptic{133.176882802, -3.229779735},
+		Equatorial: Equatorial{134.697771236, 13.765048553},
+		Distance:   368409.011414,
+	}
+	if result := MoonPosition(tm); !equalCoords(result, output) {
+		t.Errorf("expected: `%v`; got: `%v`", output, result)
+	}
+}
+
+func TestLocationMoonPosition(t *testing.T) {
+	data := TestLocationMoonPositionData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		result := input.location.MoonPosition(input.time, input.opts...)
+		if !equalCoords(result, output) {
+			t.Errorf("expected: `%v`; got: `%v`", output, result)
+		}
+	}
+}
+
+func equalCoords(a, b Coords) bool {
+	return almostEqual(a.Ecliptic.Longitude, b.Ecliptic.Longitude) &&
+		almostEqual(a.Ecliptic.Latitude, b.Ecliptic.Latitude) &&
+		almostEqual(a.Equatorial.RightAscension,
+			b.Equatorial.RightAscension) &&
+		almostEqual(a.Equatorial.Declination, b.Equatorial.Declination) &&
+		math.Abs(a.Distance-b.Distance) < 1e-3 &&
+		almostEqual(a.Horizontal.Azimuth, b.Horizontal.Azimuth) &&
+		almostEqual(a.Horizontal.Elevation, b.Horizontal.Elevation) &&
+		almostEqual(a.Horizontal.Zenith, b.Horizontal.Zenith)
+}
+
+func TestHeliocentric(t *testing.T) {
+	// Meeus, Astronomical Algorithms, example 32.a
+	j := julianTime(2448976.5) - j2000
+	output := Ecliptic{26.114283572, -2.620703103}
+	result, distance := heliocentric(planetSeries[Venus], j)
+	if !almostEqual(result.Longitude, output.Longitude) ||
+		!almostEqual(result.Latitude, output.Latitude) {
+		t.Errorf("expected: `%v`; got: `%v`", output, result)
+	}
+	if math.Abs(distance-0.724602937) > 1e-8 {
+		t.Errorf("expected: `%v`; got: `%v`", 0.724602937, distance)
+	}
+}
+
+func TestPlanetPosition(t *testing.T) {
+	// Meeus, Astronomical Algorithms, example 33.a, which uses the complete
+	// VSOP87 series
+	j := julianTime(2448976.5) - j2000
+	output := Equatorial{316.172725, -18.888011}
+	e, distance := Venus.position(j)
+	result := e.Equatorial(j.trueObliquity())
+	if math.Abs(result.RightAscension-output.RightAscension) > 1.0/3600 ||
+		math.Abs(result.Declination-output.Declination) > 1.0/3600 {
+		t.Errorf("expected: `%v`; got: `%v`", output, result)
+	}
+	if au := distance / astronomicalUnit; math.Abs(au-0.910947) > 1e-6 {
+		t.Errorf("expected: `%v`; got: `%v`", 0.910947, au)
+	}
+}
+
+var TestPositionData = []struct {
+	input  Body
+	output Coords
+}{
+	{
+		Mercury,
+		Coords{
+			Ecliptic:   Ecliptic{97.772322660, 1.674632814},
+			Equatorial: Equatorial{98.569623703, 24.882127985},
+			Distance:   194367728.897801,
+		},
+	},
+	{
+		Mars,
+		Coords{
+			Ecliptic:   Ecliptic{38.705811513, -1.038760936},
+			Equatorial: Equatorial{36.665526293, 13.418146889},
+			Distance:   266299464.829794,
+		},
+	},
+	{
+		Saturn,
+		Coords{
+			Ecliptic:   Ecliptic{349.360529489, -1.940485904},
+			Equatorial: Equatorial{350.985422779, -5.996266930},
+			Distance:   1413249277.869895,
+		},
+	},
+	{
+		Neptune,
+		Coords{
+			Ecliptic:   Ecliptic{359.897001555, -1.270348776},
+			Equatorial: Equatorial{0.410874273, -1.206482589},
+			Distance:   4469661122.331281,
+		},
+	},
+	{
+		sirius,
+		Coords{
+			Ecliptic:   Ecliptic{104.414594359, -39.603129691},
+			Equatorial: Equatorial{101.553877984, -16.741101244},
+		},
+	},
+	{
+		Sun,
+		Coords{
+			Ecliptic:   Ecliptic{90.125234979, -0.000188249},
+			Equatorial: Equatorial{90.136497406, 23.438154929},
+			Distance:   152021863.414293,
+		},
+	},
+}
+
+func TestPosition(t *testing.T) {
+	tm := time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC)
+	data := TestPositionData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		if result := Position(input, tm); !equalCoords(result, output) {
+			t.Errorf("expected: `%v`; got: `%v`", output, result)
+		}
+	}
+	if result, output := Position(Moon, tm), MoonPosition(tm); result !=
+		output {
+		t.Errorf("expected: `%v`; got: `%v`", output, result)
+	}
+}
+
+func TestLocationPosition(t *testing.T) {
+	l := Location{Latitude: 51.5, Longitude: -0.12}
+	tm := time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC)
+	output := Coords{
+		Ecliptic:   Ecliptic{38.706088545, -1.040077792},
+		Equatorial: Equatorial{36.666228178, 13.416987271},
+		Distance:   266300646.537643,
+		Horizontal: Horizontal{52.111961736, -10.584783600, 100.584783600},
+	}
+	if result := l.Position(Mars, tm); !equalCoords(result, output) {
+		t.Errorf("expected: `%v`; got: `%v`", output, result)
+	}
+}
+
+type TestLocationSunCrossingInput struct {
+	location  Location
+	date      time.Time
+	elevation float64
+	rising    bool
+}
+
+var TestLocationSunCrossingData = []struct {
+	input  TestLocationSunCrossingInput
+	output time.Time
+	err    error
+}{
+	{
+		TestLocationSunCrossingInput{
+			Location{51.5, -0.12, 0},
+			time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC), 15, true,
+		},
+		time.Date(2024, 6, 21, 5, 41, 16, 0, time.UTC),
+		nil,
+	},
+	{
+		TestLocationSunCrossingInput{
+			Location{51.5, -0.12, 0},
+			time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC), 15, false,
+		},
+		time.Date(2024, 6, 21, 18, 23, 32, 0, time.UTC),
+		nil,
+	},
+	{
+		TestLocationSunCrossingInput{
+			Location{51.5, -0.12, 0},
+			time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC), 70, true,
+		},
+		time.Time{},
+		ErrNoCrossing,
+	},
+}
+
+func TestLocationSunCrossing(t *testing.T) {
+	data := TestLocationSunCrossingData
+	for i := 0; i < len(data); i++ {
+		input, output, out := data[i].input, data[i].output, data[i].err
+		result, err := input.location.SunCrossing(input.date,
+			input.elevation, input.rising)
+		if err != out {
+			t.Errorf("expected `%s`; got: `%s`", out, err)
+		}
+		if !result.Equal(output) {
+			t.Errorf("expected: `%s`; got: `%s`", output, result)
+		}
+	}
+}
+
+type TestLocationSunAzimuthCrossingInput struct {
+	location Location
+	date     time.Time
+	azimuth  float64
+}
+
+var TestLocationSunAzimuthCrossingData = []struct {
+	input  TestLocationSunAzimuthCrossingInput
+	output time.Time
+}{
+	{
+		TestLocationSunAzimuthCrossingInput{
+			Location{51.5, -0.12, 0},
+			time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC), 90,
+		},
+		time.Date(2024, 6, 21, 7, 23, 3, 0, time.UTC),
+	},
+	{
+		TestLocationSunAzimuthCrossingInput{
+			Location{51.5, -0.12, 0},
+			time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC), 270,
+		},
+		time.Date(2024, 6, 21, 16, 41, 46, 0, time.UTC),
+	},
+	{
+		TestLocationSunAzimuthCrossingInput{
+			Location{-33.9, 151.2, 0},
+			time.Date(2024, 6, 21, 12, 0, 0, 0,
+				time.FixedZone("AEST", 36000)), 300,
+		},
+		time.Date(2024, 6, 21, 16, 39, 39, 0,
+			time.FixedZone("AEST", 36000)),
+	},
+}
+
+func TestLocationSunAzimuthCrossing(t *testing.T) {
+	data := TestLocationSunAzimuthCrossingData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		result, err := input.location.SunAzimuthCrossing(input.date,
+			input.azimuth)
+		if err != nil || !result.Equal(output) {
+			t.Errorf("expected: `%s`; got: `%s` (%v)", output, result, err)
+		}
+	}
+}
+
+var TestEquationOfTimeData = []struct {
+	input  time.Time
+	output time.Duration
+}{
+	{
+		time.Date(1992, 10, 13, 0, 0, 0, 0, time.UTC),
+		13*time.Minute + 42028*time.Millisecond,
+	},
+	{
+		time.Date(2024, 2, 11, 12, 0, 0, 0, time.UTC),
+		-(14*time.Minute + 12059*time.Millisecond),
+	},
+	{
+		time.Date(2024, 11, 3, 13, 0, 0, 0, time.FixedZone("CET", 3600)),
+		16*time.Minute + 26440*time.Millisecond,
+	},
+}
+
+func TestEquationOfTime(t *testing.T) {
+	data := TestEquationOfTimeData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		result := EquationOfTime(input).Round(time.Millisecond)
+		if result != output {
+			t.Errorf("expected: `%s`; got: `%s`", output, result)
+		}
+	}
+}
+
+type TestLocationSolarTimeInput struct {
+	location Location
+	time     time.Time
+}
+
+var TestLocationSolarTimeData = []struct {
+	input    TestLocationSolarTimeInput
+	mean     string
+	apparent string
+}{
+	{
+		TestLocationSolarTimeInput{
+			Location{51.5, -0.12, 0},
+			time.Date(2024, 11, 3, 12, 0, 0, 0, time.UTC),
+		},
+		"2024-11-03T11:59:31+00:00",
+		"2024-11-03T12:15:58+00:15",
+	},
+	{
+		TestLocationSolarTimeInput{
+			Location{40.0, 116.4, 0},
+			time.Date(2024, 11, 3, 20, 0, 0, 0,
+				time.FixedZone("CST", 28800)),
+		},
+		"2024-11-03T19:45:36+07:45",
+		"2024-11-03T20:02:02+08:02",
+	},
+}
+
+func TestLocationSolarTime(t *testing.T) {
+	data := TestLocationSolarTimeData
+	for i := 0; i < len(data); i++ {
+		input := data[i].input
+		mean := input.location.MeanSolarTime(input.time)
+		if result := gregorianTime(mean).String(); result != data[i].mean {
+			t.Errorf("expected: `%s`; got: `%s`", data[i].mean, result)
+		}
+		result := input.location.FromMeanSolarTime(mean)
+		if d := result.Sub(input.time); d < -time.Second || d > time.Second {
+			t.Errorf("expected: `%s`; got: `%s`", input.time, result)
+		}
+		apparent := input.location.ApparentSolarTime(input.time)
+		if result := gregorianTime(apparent).String(); result !=
+			data[i].apparent {
+			t.Errorf("expected: `%s`; got: `%s`", data[i].apparent, result)
+		}
+		result = input.location.FromApparentSolarTime(apparent)
+		if d := result.Sub(input.time); d < -time.Second || d > time.Second {
+			t.Errorf("expected: `%s`; got: `%s`", input.time, result)
+		}
+	}
+}
+
+type calendarDate struct {
+	year  int
+	month int
+	day   float64
+}
+
+var TestReformationJulianData = []struct {
+	input  calendarDate
+	output julianTime
+}{
+	{calendarDate{2000, 1, 1.5}, 2451545.0},
+	{calendarDate{1957, 10, 4.81}, 2436116.31},
+	{calendarDate{1600, 12, 31}, 2305812.5},
+	{calendarDate{1582, 10, 15}, 2299160.5},
+	{calendarDate{1582, 10, 4}, 2299159.5},
+	{calendarDate{333, 1, 27.5}, 1842713.0},
+	{calendarDate{-1000, 7, 12.5}, 1356001.0},
+	{calendarDate{-1001, 8, 17.9}, 1355671.4},
+	{calendarDate{-4712, 1, 1.5}, 0.0},
+}
+
+func TestReformationJulian(t *testing.T) {
+	data := TestReformationJulianData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		result, err := CatholicReformation.julian(input.year, input.month,
+			input.day)
+		if err != nil || !result.almostEqual(output) {
+			t.Errorf("expected: `%f`; got: `%f` (%v)", output, result, err)
+		}
+	}
+}
+
+func TestReformationDate(t *testing.T) {
+	data := TestReformationJulianData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].output, data[i].input
+		y, m, d := CatholicReformation.date(input)
+		if y != output.year || m != output.month ||
+			!almostEqual(d, output.day) {
+			t.Errorf("expected: `%v`; got: `%d %d %f`", output, y, m, d)
+		}
+	}
+}
+
+type TestReformationTimeInput struct {
+	reformation Reformation
+	year        int
+	month       time.Month
+	day         int
+}
+
+var TestReformationTimeData = []struct {
+	input  TestReformationTimeInput
+	output time.Time
+	err    error
+}{
+	{
+		TestReformationTimeInput{BritishReformation, 1752, 9, 2},
+		time.Date(1752, 9, 13, 0, 0, 0, 0, time.UTC),
+		nil,
+	},
+	{
+		TestReformationTimeInput{BritishReformation, 1752, 9, 14},
+		time.Date(1752, 9, 14, 0, 0, 0, 0, time.UTC),
+		nil,
+	},
+	{
+		TestReformationTimeInput{BritishReformation, 1752, 9, 5},
+		time.Time{},
+		ErrSkippedDate,
+	},
+	{
+		TestReformationTimeInput{CatholicReformation, 1752, 9, 5},
+		time.Date(1752, 9, 5, 0, 0, 0, 0, time.UTC),
+		nil,
+	},
+	{
+		TestReformationTimeInput{RussianReformation, 1917, 10, 25},
+		time.Date(1917, 11, 7, 0, 0, 0, 0, time.UTC),
+		nil,
+	},
+	{
+		TestReformationTimeInput{
+			NewReformation(time.Date(1700, 3, 1, 0, 0, 0, 0, time.UTC)),
+			1700, 2, 18,
+		},
+		time.Date(1700, 2, 28, 0, 0, 0, 0, time.UTC),
+		nil,
+	},
+}
+
+func TestReformationTime(t *testing.T) {
+	data := TestReformationTimeData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		result, err := input.reformation.Time(input.year, input.month,
+			input.day)
+		if err != data[i].err || !result.Equal(output) {
+			t.Errorf("expected: `%s` (%v); got: `%s` (%v)", output,
+				data[i].err, result, err)
+		}
+		if err != nil {
+			continue
+		}
+		y, m, d := input.reformation.Date(result)
+		if y != input.year || m != input.month || d != input.day {
+			t.Errorf("expected: `%v`; got: `%d %d %d`", input, y, m, d)
+		}
+	}
+}
+
+var TestJulianDateData = []struct {
+	input  time.Time
+	output JulianDate
+	mjd    float64
+	text   string
+}{
+	{
+		time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC),
+		NewJulianDate(2451545, 0), 51544.5, "JD 2451545.0",
+	},
+	{
+		time.Date(1858, 11, 17, 1, 0, 0, 0, time.FixedZone("CET", 3600)),
+		NewJulianDate(2400000.5, 0), 0, "JD 2400000.5",
+	},
+	{
+		time.Date(-4713, 11, 24, 12, 0, 0, 0, time.UTC),
+		NewJulianDate(0, 0), -2400000.5, "JD 0.0",
+	},
+	{
+		time.Date(2024, 6, 21, 18, 0, 0, 0, time.UTC),
+		NewJulianDate(2460483.25, 0), 60482.75, "JD 2460483.25",
+	},
+	{
+		time.Date(2024, 6, 21, 18, 0, 0, 123456789, time.UTC),
+		NewJulianDate(2460483, 0.2500014288980208), 60482.75000142889,
+		"JD 2460483.250001428898021",
+	},
+	{
+		time.Date(1957, 10, 4, 19, 26, 24, 0, time.UTC),
+		NewJulianDate(2436116, 0.31000000000000005), 36115.81,
+		"JD 2436116.31",
+	},
+}
+
+func TestJulianDate(t *testing.T) {
+	data := TestJulianDateData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		if result := JulianDateFromTime(input); result != output {
+			t.Errorf("expected: `%s`; got: `%s`", output, result)
+		}
+		if result := output.Time(); !result.Equal(input) {
+			t.Errorf("expected: `%s`; got: `%s`", input, result)
+		}
+		if result := output.MJD(); !almostEqual(result, data[i].mjd) {
+			t.Errorf("expected: `%f`; got: `%f`", data[i].mjd, result)
+		}
+		if result := output.String(); result != data[i].text {
+			t.Errorf("expected: `%s`; got: `%s`", data[i].text, result)
+		}
+		if result, err := ParseJulianDate(data[i].text); err != nil ||
+			result.Sub(output) != 0 {
+			t.Errorf("expected: `%s`; got: `%s` (%v)", output, result, err)
+		}
+		mjd := fmt.Sprintf("MJD %v", data[i].mjd)
+		if result, err := ParseJulianDate(mjd); err != nil ||
+			result.Sub(output).Abs() > time.Microsecond {
+			t.Errorf("expected: `%s`; got: `%s` (%v)", output, result, err)
+		}
+	}
+}
+
+func TestJulianDateArithmetic(t *testing.T) {
+	j := NewJulianDate(2451545, 0)
+	if result := j.Add(36 * time.Hour); result != NewJulianDate(2451546.5,
+		0) {
+		t.Errorf("expected: `%s`; got: `%s`", NewJulianDate(2451546.5, 0),
+			result)
+	}
+	if result := j.Add(-time.Nanosecond).Add(time.Nanosecond); result != j {
+		t.Errorf("expected: `%s`; got: `%s`", j, result)
+	}
+	if result := NewJulianDate(2451546.5, 0).Sub(j); result != 36*time.Hour {
+		t.Errorf("expected: `%s`; got: `%s`", 36*time.Hour, result)
+	}
+	if result := j.Add(time.Nanosecond).Sub(j); result != time.Nanosecond {
+		t.Errorf("expected: `%s`; got: `%s`", time.Nanosecond, result)
+	}
+	if result := J2000Epoch().Sub(NewJulianDate(0, 0)); result !=
+		math.MaxInt64 {
+		t.Errorf("expected: `%s`; got: `%s`", time.Duration(math.MaxInt64),
+			result)
+	}
+	if result := NewJulianDate(0, 0).Sub(J2000Epoch()); result !=
+		math.MinInt64 {
+		t.Errorf("expected: `%s`; got: `%s`", time.Duration(math.MinInt64),
+			result)
+	}
+	if result := NewJulianDate(2488070, 0).J2000Centuries(); !almostEqual(
+		result, 1) {
+		t.Errorf("expected: `%f`; got: `%f`", 1.0, result)
+	}
+	if result := J2000Epoch().J2000Centuries(); result != 0 {
+		t.Errorf("expected: `%f`; got: `%f`", 0.0, result)
+	}
+	e := time.Date(2000, 1, 1, 11, 58, 55, 816000000, time.UTC)
+	if result := J2000Epoch().Time(); !result.Equal(e) {
+		t.Errorf("expected: `%s`; got: `%s`", e, result)
+	}
+}
+
+var TestNewJulianDateData = []struct {
+	input  [2]float64
+	output [2]float64
+}{
+	{[2]float64{2451545.25, 0}, [2]float64{2451545, 0.25}},
+	{[2]float64{2400000.5, 51544.75}, [2]float64{2451545, 0.25}},
+	{[2]float64{2451546, -0.75}, [2]float64{2451545, 0.25}},
+	{[2]float64{-0.25, 0}, [2]float64{-1, 0.75}},
+}
+
+func TestNewJulianDate(t *testing.T) {
+	data := TestNewJulianDateData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		d, f := NewJulianDate(input[0], input[1]).Parts()
+		if result := [2]float64{d, f}; result != output {
+			t.Errorf("expected: `%v`; got: `%v`", output, result)
+		}
+	}
+}
+
+var TestParseJulianDateErrorData = []string{
+	"", "2451545.0", "JD", "JD x", "TJD 13000", "JD 1 2", "JD NaN", "JD .",
+	"MJD -.", "JD 1_000", "JD 2_451_545.5", "JD 0x1p21",
+}
+
+func TestParseJulianDateError(t *testing.T) {
+	data := TestParseJulianDateErrorData
+	for i := 0; i < len(data); i++ {
+		if _, err := ParseJulianDate(data[i]); !errors.Is(err,
+			ErrInvalidJulianDate) {
+			t.Errorf("expected: `%v`; got: `%v`", ErrInvalidJulianDate, err)
+		}
+	}
+}
+
+func TestJulianDateMarshal(t *testing.T) {
+	v := struct {
+		Epoch JulianDate `json:"epoch"`
+		Times map[JulianDate]string
+	}{
+		NewJulianDate(2451545.5, 0),
+		map[JulianDate]string{NewJulianDate(2400000.5, 0): "mjd"},
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := `{"epoch":2451545.5,"Times":{"JD 2400000.5":"mjd"}}`
+	if string(b) != output {
+		t.Errorf("expected: `%s`; got: `%s`", output, b)
+	}
+	var j JulianDate
+	output = "JD 2451545.5"
+	for _, s := range []string{`2451545.5`, `"JD 2451545.5"`,
+		`"MJD 51545"`} {
+		if err := json.Unmarshal([]byte(s), &j); err != nil ||
+			j.String() != output {
+			t.Errorf("expected: `%s`; got: `%s` (%v)", output, j, err)
+		}
+	}
+	if err := json.Unmarshal([]byte(`2460483.2500014288980208`), &j); err !=
+		nil || j.Time().Nanosecond() != 123456789 {
+		t.Errorf("expected: `%d`; got: `%d` (%v)", 123456789,
+			j.Time().Nanosecond(), err)
+	}
+	if err := json.Unmarshal([]byte(`null`), &j); err != nil ||
+		j.String() != "JD 2460483.250001428898021" {
+		t.Errorf("expected: `%s`; got: `%s` (%v)",
+			"JD 2460483.250001428898021", j, err)
+	}
+	if err := json.Unmarshal([]byte(`true`), &j); !errors.Is(err,
+		ErrInvalidJulianDate) {
+		t.Errorf("expected: `%v`; got: `%v`", ErrInvalidJulianDate, err)
+	}
+}
+
+var TestJulianCalendarDateData = []struct {
+	input JulianCalendarDate
+	jd    JulianDate
+	time  time.Time
+}{
+	{
+		JulianCalendarDate{1582, 10, 4},
+		NewJulianDate(2299159.5, 0),
+		time.Date(1582, 10, 14, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		JulianCalendarDate{2024, 2, 29},
+		NewJulianDate(2460382.5, 0),
+		time.Date(2024, 3, 13, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		JulianCalendarDate{-43, 3, 15},
+		NewJulianDate(1705425.5, 0),
+		time.Date(-43, 3, 13, 0, 0, 0, 0, time.UTC),
+	},
+}
+
+func TestJulianCalendarDate(t *testing.T) {
+	data := TestJulianCalendarDateData
+	for i := 0; i < len(data); i++ {
+		input := data[i].input
+		if result := input.JD(); result != data[i].jd {
+			t.Errorf("expected: `%s`; got: `%s`", data[i].jd, result)
+		}
+		if result := input.Time(); !result.Equal(data[i].time) {
+			t.Errorf("expected: `%s`; got: `%s`", data[i].time, result)
+		}
+		if result := NewJulianCalendarDate(data[i].time); result != input {
+			t.Errorf("expected: `%s`; got: `%s`", input, result)
+		}
+		if result := NewJulianCalendarDateFromJD(data[i].jd.Add(
+			18 * time.Hour)); result != input {
+			t.Errorf("expected: `%s`; got: `%s`", input, result)
+		}
+	}
+}
+
+var TestCalendarJulianData = []struct {
+	input     calendarDate
+	gregorian bool
+	output    julianTime
+}{
+	{calendarDate{1582, 10, 4}, true, 2299149.5},
+	{calendarDate{1582, 10, 15}, false, 2299170.5},
+	{calendarDate{-4713, 11, 24.5}, true, 0.0},
+	{calendarDate{9999, 12, 31}, true, 5373483.5},
+}
+
+func TestCalendarJulian(t *testing.T) {
+	data := TestCalendarJulianData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		result := calendarJulian(input.year, input.month, input.day,
+			data[i].gregorian)
+		if !result.almostEqual(output) {
+			t.Errorf("expected: `%f`; got: `%f`", output, result)
+		}
+		y, m, d := output.calendar(data[i].gregorian)
+		if y != input.year || m != input.month || !almostEqual(d, input.day) {
+			t.Errorf("expected: `%v`; got: `%d %d %f`", input, y, m, d)
+		}
+	}
+}
+
+var TestGregorianTimeJulianRoundTripData = []time.Time{
+	time.Date(-4712, 11, 24, 12, 0, 0, 0, time.UTC),
+	time.Date(-584, 5, 28, 15, 7, 12, 0, time.UTC),
+	time.Date(1066, 10, 14, 9, 30, 0, 0, time.UTC),
+	time.Date(1582, 10, 15, 0, 0, 0, 0, time.UTC),
+	time.Date(2024, 8, 5, 21, 18, 37, 250000000, time.UTC),
+	time.Date(2999, 12, 31, 23, 59, 59, 500000000, time.UTC),
+}
+
+func TestGregorianTimeJulianRoundTrip(t *testing.T) {
+	data := TestGregorianTimeJulianRoundTripData
+	for i := 0; i < len(data); i++ {
+		input := data[i]
+		j := gregorianTime(input).julian()
+		result := time.Time(j.gregorian())
+		if d := result.Sub(input); d < -100*time.Microsecond ||
+			d > 100*time.Microsecond {
+			t.Errorf("expected: `%s`; got: `%s`", input, result)
+		}
+	}
+}
+
+var TestTaiMinusUTCData = []struct {
+	input  time.Time
+	output time.Duration
+}{
+	{time.Date(1960, 12, 31, 0, 0, 0, 0, time.UTC), 0},
+	{time.Date(1961, 1, 1, 0, 0, 0, 0, time.UTC), 1422818 * time.Microsecond},
+	{time.Date(1968, 2, 1, 0, 0, 0, 0, time.UTC), 6185682 * time.Microsecond},
+	{time.Date(1972, 1, 1, 0, 0, 0, 0, time.UTC), 10 * time.Second},
+	{time.Date(1999, 1, 1, 0, 0, 0, 0, time.UTC), 32 * time.Second},
+	{time.Date(2016, 12, 31, 23, 59, 59, 0, time.UTC), 36 * time.Second},
+	{time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), 37 * time.Second},
+}
+
+func TestTaiMinusUTC(t *testing.T) {
+	data := TestTaiMinusUTCData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		if result := taiMinusUTC(input).Round(time.Microsecond); result !=
+			output {
+			t.Errorf("expected: `%s`; got: `%s`", output, result)
+		}
+	}
+}
+
+type TestConvertTimeInput struct {
+	time time.Time
+	from TimeScale
+	to   TimeScale
+}
+
+var TestConvertTimeData = []struct {
+	input  TestConvertTimeInput
+	output time.Time
+}{
+	{
+		TestConvertTimeInput{
+			time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC), UTC, TAI,
+		},
+		time.Date(2024, 6, 21, 12, 0, 37, 0, time.UTC),
+	},
+	{
+		TestConvertTimeInput{
+			time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC), UTC, TT,
+		},
+		time.Date(2024, 6, 21, 12, 1, 9, 184000000, time.UTC),
+	},
+	{
+		TestConvertTimeInput{
+			time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC), TT, UTC,
+		},
+		time.Date(2000, 1, 1, 11, 58, 55, 816000000, time.UTC),
+	},
+	{
+		TestConvertTimeInput{
+			time.Date(2017, 1, 1, 0, 0, 35, 0, time.UTC), TAI, UTC,
+		},
+		time.Date(2016, 12, 31, 23, 59, 59, 0, time.UTC),
+	},
+	{
+		TestConvertTimeInput{
+			time.Date(2017, 1, 1, 0, 0, 37, 0, time.UTC), TAI, UTC,
+		},
+		time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		TestConvertTimeInput{
+			time.Date(1977, 1, 1, 0, 0, 0, 0, time.UTC), TAI, TT,
+		},
+		time.Date(1977, 1, 1, 0, 0, 32, 184000000, time.UTC),
+	},
+	{
+		TestConvertTimeInput{
+			time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC), UT1, UTC,
+		},
+		time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC),
+	},
+	{
+		TestConvertTimeInput{
+			time.Date(1700, 7, 2, 12, 0, 0, 0, time.UTC), UTC, TT,
+		},
+		time.Date(1700, 7, 2, 12, 0, 8, 909688681, time.UTC),
+	},
+	{
+		TestConvertTimeInput{
+			time.Date(1700, 1, 1, 0, 0, 0, 0, time.UTC), UT1, UTC,
+		},
+		time.Date(1700, 1, 1, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		TestConvertTimeInput{
+			time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), UTC, TT,
+		},
+		time.Date(2027, 1, 1, 0, 1, 9, 184000000, time.UTC),
+	},
+}
+
+func TestConvertTime(t *testing.T) {
+	data := TestConvertTimeData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		result := ConvertTime(input.time, input.from, input.to)
+		if !result.Equal(output) {
+			t.Errorf("expected: `%s`; got: `%s`", output, result)
+		}
+		if back := ConvertTime(result, input.to, input.from); !back.Equal(
+			input.time) {
+			t.Errorf("expected: `%s`; got: `%s`", input.time, back)
+		}
+	}
+}
+
+func TestConvertTimeLeapSecondsExpiry(t *testing.T) {
+	e := time.Time(leapSecondsExpiry.gregorian())
+	offset := func(t time.Time) time.Duration {
+		return ConvertTime(t, UTC, TT).Sub(t)
+	}
+	before, after := offset(e.Add(-time.Second)), offset(e.Add(time.Second))
+	if d := after - before; d < 0 || d > time.Millisecond {
+		t.Errorf("expected: `%s`; got: `%s`", before, after)
+	}
+}
+
+var TestDeltaTData = []struct {
+	input  float64
+	output time.Duration
+}{
+	{-1000, 25427680 * time.Millisecond},
+	{1000, 1574200 * time.Millisecond},
+	{1700, 8830 * time.Millisecond},
+	{1900, -2790 * time.Millisecond},
+	{2000, 63860 * time.Millisecond},
+	{2200, 442080 * time.Millisecond},
+}
+
+func TestDeltaT(t *testing.T) {
+	data := TestDeltaTData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		if result := DeltaT(input).Round(time.Millisecond); result !=
+			output {
+			t.Errorf("expected: `%s`; got: `%s`", output, result)
+		}
+	}
+}
+
+func TestDeltaTOverride(t *testing.T) {
+	defer func() { DeltaTOverride = nil }()
+	DeltaTOverride = func(year float64) (time.Duration, bool) {
+		return 69 * time.Second, year >= 2020 && year < 2030
+	}
+	if result := DeltaT(2024.5); result != 69*time.Second {
+		t.Errorf("expected: `%s`; got: `%s`", 69*time.Second, result)
+	}
+	if result := DeltaT(1700).Round(time.Millisecond); result !=
+		8830*time.Millisecond {
+		t.Errorf("expected: `%s`; got: `%s`", 8830*time.Millisecond, result)
+	}
+}
+
+var TestTimeScaleStringData = []struct {
+	input  TimeScale
+	output string
+}{
+	{UTC, "UTC"},
+	{TAI, "TAI"},
+	{TT, "TT"},
+	{UT1, "UT1"},
+	{TimeScale(9), "TimeScale(9)"},
+}
+
+func TestTimeScaleString(t *testing.T) {
+	data := TestTimeScaleStringData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		if result := input.String(); result != output {
+			t.Errorf("expected: `%s`; got: `%s`", output, result)
+		}
+	}
+}
+
+var TestPhaseNameStringData = []struct {
+	input  PhaseName
+	output string
+}{
+	{NewMoon, "New Moon"},
+	{WaxingCrescent, "Waxing Crescent"},
+	{FirstQuarter, "First Quarter"},
+	{WaxingGibbous, "Waxing Gibbous"},
+	{FullMoon, "Full Moon"},
+	{WaningGibbous, "Waning Gibbous"},
+	{LastQuarter, "Last Quarter"},
+	{WaningCrescent, "Waning Crescent"},
+	{PhaseName(9), "PhaseName(9)"},
+}
+
+func TestPhaseNameString(t *testing.T) {
+	data := TestPhaseNameStringData
+	for i := 0; i < len(data); i++ {
+		input, output := data[i].input, data[i].output
+		if result := input.String(); result != output {
+			t.Errorf("expected: `%s`; got: `%s`", output, result)
+		}
+	}
+}
+
+func TestPhaseNameUnmarshalText(t *testing.T) {
+	data := TestPhaseNameStringData
+	for i := 0; i < len(data)-1; i++ {
+		var result PhaseName
+		err := result.UnmarshalText([]byte(data[i].output))
+		if err != nil || result != data[i].input {
+			t.Errorf("expected: `%v`; got: `%v` (%v)", data[i].input, result,
+				err)
+		}
+	}
+	var p LunarPhase
+	err := json.Unmarshal([]byte(`{"name":"Blue Moon"}`), &p)
+	if !errors.Is(err, ErrInvalidPhaseName) {
+		t.Errorf("expected: `%v`; got: `%v`", ErrInvalidPhaseName, err)
+	}
+}
+
+var TestBodyStringData = []struct {
+	input  Body
+	output string
+}{
+	{Mercury, "Mercury"},
+	{Venus, "Venus"},
+	{Mars, "Mars"},
+	{Jupiter, "Jupiter"},
+	{Saturn, "Saturn"},
+	{Uranus, "Uranus"},
+	{Neptune, "Neptune"},
+	{Planet(9), "Planet(9)"},
+	{Sun, "Sun"},
+	{Moon, "Moon"},
+	{Luminary(2), "Luminary(2)"},
+	{sirius, "Sirius"},
+}
+
+func TestPositionUnknownBody(t *testing.T) {
+	tm := time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC)
+	for _, b := range []Body{Planet(42), Luminary(2)} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected a panic for `%s`", b)
+				}
+			}()
+			Position(b, tm)
+		}()
+	}
+}
+
+func TestBodyString(t *testing.T) {
+	data := TestBodyStringData
 	for i := 0; i < len(data); i++ {
 		input, output := data[i].input, data[i].output
-		result := input.location.sunsetTime(input.day)
-		if output.gregorian() != result.gregorian() {
-			t.Errorf("expected: `%s`; got: `%s`", result.gregorian(),
-				output.gregorian())
+		if result := input.String(); result != output {
+			t.Errorf("expected: `%s`; got: `%s`", output, result)
 		}
 	}
 }
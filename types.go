@@ -12,7 +12,18 @@ type julianTime float64
 
 type julianDay julianTime
 
-// Altitude is the height in meters of an object above sea level
+// JulianDate is a Julian Date in UTC: the number of days, and fractions of a
+// day, since noon on 1 January 4713 BC in the proleptic Julian calendar. It is
+// held in two parts so as to resolve times to better than a nanosecond.
+type JulianDate struct {
+	// day is the whole number of days, so that the day begins at noon
+	day float64
+	// fraction is the fraction of the day since noon, from 0 up to 1
+	fraction float64
+}
+
+// Altitude is the height in meters of an object above sea level, which is
+// negative for places below it
 type Altitude float64
 
 // Location is the three-dimensional position of an object above the globe.
@@ -20,5 +31,278 @@ type Altitude float64
 type Location struct {
 	Latitude  float64  `json:"latitude" validate:"min=-90,max=90"`
 	Longitude float64  `json:"longitude" validate:"min=-180,max=180"`
-	Altitude  Altitude `json:"altitude" validate:"min=0"`
+	Altitude  Altitude `json:"altitude" validate:"min=-11000"`
+}
+
+// Weather is the state of the atmosphere at a Location, which affects the
+// refraction of light from objects in the sky. Temperature is in degrees
+// Celsius and Pressure in millibars.
+type Weather struct {
+	Temperature float64 `json:"temperature"`
+	Pressure    float64 `json:"pressure"`
+}
+
+// Option adjusts the conditions assumed by a calculation
+type Option func(*options)
+
+// options holds the conditions set by Options
+type options struct {
+	// weather is the Weather allowing for refraction, if any was given
+	weather *Weather
+	// horizon is the Horizon over which the Sun rises, if any was given
+	horizon Horizon
+}
+
+// HorizonPoint is the elevation (in degrees) of the skyline in the direction
+// of an Azimuth (in degrees clockwise from north)
+type HorizonPoint struct {
+	Azimuth   float64 `json:"azimuth"`
+	Elevation float64 `json:"elevation"`
 }
+
+// Horizon is the profile of the skyline seen from a Location, such as
+// mountains or buildings, given by HorizonPoints in any order
+type Horizon []HorizonPoint
+
+// SunTimes holds the times of sunrise, solar noon and sunset at a Location on
+// a particular date. Times that do not occur on that date are left as the
+// zero time.Time.
+type SunTimes struct {
+	Sunrise   time.Time `json:"sunrise"`
+	SolarNoon time.Time `json:"solarNoon"`
+	Sunset    time.Time `json:"sunset"`
+}
+
+// DawnDusk holds the times at which the Sun climbs above and sinks below a
+// particular elevation. AlwaysAbove or AlwaysBelow is set when the Sun stays
+// on one side of that elevation all day, as in polar day and night.
+type DawnDusk struct {
+	Dawn        time.Time `json:"dawn"`
+	Dusk        time.Time `json:"dusk"`
+	AlwaysAbove bool      `json:"alwaysAbove"`
+	AlwaysBelow bool      `json:"alwaysBelow"`
+}
+
+// Interval is the period of time between Start and End
+type Interval struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Twilight holds the times bounding each band of twilight at a Location on a
+// particular date. The golden hour is when the Sun is between -4° and 6°
+// elevation, and the blue hour is when it is between -6° and -4°.
+type Twilight struct {
+	Civil             DawnDusk `json:"civil"`
+	Nautical          DawnDusk `json:"nautical"`
+	Astronomical      DawnDusk `json:"astronomical"`
+	MorningGoldenHour Interval `json:"morningGoldenHour"`
+	EveningGoldenHour Interval `json:"eveningGoldenHour"`
+	MorningBlueHour   Interval `json:"morningBlueHour"`
+	EveningBlueHour   Interval `json:"eveningBlueHour"`
+}
+
+// SeasonTimes holds the instants of the equinoxes and solstices in a
+// particular year
+type SeasonTimes struct {
+	MarchEquinox     time.Time `json:"marchEquinox"`
+	JuneSolstice     time.Time `json:"juneSolstice"`
+	SeptemberEquinox time.Time `json:"septemberEquinox"`
+	DecemberSolstice time.Time `json:"decemberSolstice"`
+}
+
+// vsop87 is a series of periodic terms from the VSOP87 theory of the
+// planets, grouped by the power of time by which they are multiplied. Each
+// term gives the amplitude (in units of 0.00000001), phase (in radians) and
+// frequency (in radians per Julian millennium) of a cosine.
+type vsop87 [][]struct{ a, b, c float64 }
+
+// Coords is the position of the centre of a Body, with its Distance in
+// kilometers, which is 0 for a Star. Horizontal is only set for positions
+// seen from a Location.
+type Coords struct {
+	Ecliptic   Ecliptic   `json:"ecliptic"`
+	Equatorial Equatorial `json:"equatorial"`
+	Distance   float64    `json:"distance"`
+	Horizontal Horizontal `json:"horizontal"`
+}
+
+// BodyTimes holds the times at which a Body rises, transits the meridian and
+// sets at a Location on a particular date. Times that do not occur on that
+// date are left as the zero time.Time.
+type BodyTimes struct {
+	Rise    time.Time `json:"rise"`
+	Transit time.Time `json:"transit"`
+	Set     time.Time `json:"set"`
+}
+
+// MoonTimes holds the times of moonrise, moonset and the Moon's transit of
+// the meridian at a Location on a particular date, of which there may be
+// none or more than one. AlwaysUp or AlwaysDown is set when the Moon
+// neither rises nor sets that day.
+type MoonTimes struct {
+	Rise       []time.Time `json:"rise"`
+	Set        []time.Time `json:"set"`
+	Transit    []time.Time `json:"transit"`
+	AlwaysUp   bool        `json:"alwaysUp"`
+	AlwaysDown bool        `json:"alwaysDown"`
+}
+
+// LunarPhase describes the appearance of the Moon. Angle is the phase angle
+// (in degrees) between the directions of the Sun and the observer seen from
+// the Moon, which is 0 at full Moon, and Illumination the fraction of its
+// disk that is lit. Age is the time (in days) since the last new Moon.
+// BrightLimb is the position angle (in degrees) of the midpoint of the
+// bright limb, measured eastwards from the north point of the disk, and
+// ZenithBrightLimb the same angle measured from the point towards the
+// zenith, which is only set for phases seen from a Location.
+type LunarPhase struct {
+	Angle            float64   `json:"angle"`
+	Illumination     float64   `json:"illumination"`
+	Waxing           bool      `json:"waxing"`
+	Name             PhaseName `json:"name"`
+	Age              float64   `json:"age"`
+	BrightLimb       float64   `json:"brightLimb"`
+	ZenithBrightLimb float64   `json:"zenithBrightLimb"`
+}
+
+// PhaseTime is the instant at which the Moon reaches one of its principal
+// phases: NewMoon, FirstQuarter, FullMoon or LastQuarter
+type PhaseTime struct {
+	Phase PhaseName `json:"phase"`
+	Time  time.Time `json:"time"`
+}
+
+// PhaseName is the name given to a phase of the Moon, each of which covers
+// an eighth of the lunar cycle
+type PhaseName int
+
+// The PhaseNames, in order through the lunar cycle from one new Moon to the
+// next
+const (
+	NewMoon PhaseName = iota
+	WaxingCrescent
+	FirstQuarter
+	WaxingGibbous
+	FullMoon
+	WaningGibbous
+	LastQuarter
+	WaningCrescent
+)
+
+// Body is an object in the sky whose position can be found: a Planet, a
+// Luminary or a Star
+type Body interface {
+	position(j julianTime) (Ecliptic, float64)
+	String() string
+}
+
+// Planet is one of the major planets other than the Earth, whose positions
+// are found from VSOP87 series truncated to the terms given by Meeus in
+// Astronomical Algorithms. These are good to better than an arcminute for
+// several centuries either side of 2000, and degrade further from it.
+type Planet int
+
+// The Planets, in order of distance from the Sun
+const (
+	Mercury Planet = iota
+	Venus
+	Mars
+	Jupiter
+	Saturn
+	Uranus
+	Neptune
+)
+
+// Luminary is the Sun or the Moon
+type Luminary int
+
+const (
+	// Sun is found from VSOP87, like the Planets
+	Sun Luminary = iota
+
+	// Moon is found from the ELP-2000/82 series given by Meeus
+	Moon
+)
+
+// Star is a fixed star, at Equatorial coordinates referred to the mean
+// equator and equinox of J2000Epoch. Its proper motion and parallax are
+// ignored.
+type Star struct {
+	Name string `json:"name"`
+	Equatorial
+}
+
+// Horizontal is the position of an object in the sky as seen by an observer.
+// Azimuth is measured in degrees clockwise from north, Elevation in degrees
+// above the horizon, and Zenith is the angle from directly overhead.
+type Horizontal struct {
+	Azimuth   float64 `json:"azimuth"`
+	Elevation float64 `json:"elevation"`
+	Zenith    float64 `json:"zenith"`
+}
+
+// Ecliptic is a position on the celestial sphere measured from the ecliptic
+// and the vernal equinox, with Longitude and Latitude in degrees
+type Ecliptic struct {
+	Longitude float64 `json:"longitude"`
+	Latitude  float64 `json:"latitude"`
+}
+
+// Equatorial is a position on the celestial sphere measured from the
+// celestial equator and the vernal equinox, with RightAscension and
+// Declination in degrees
+type Equatorial struct {
+	RightAscension float64 `json:"rightAscension"`
+	Declination    float64 `json:"declination"`
+}
+
+// Galactic is a position on the celestial sphere measured from the plane of
+// the Milky Way and the direction of its centre, with Longitude and Latitude
+// in degrees
+type Galactic struct {
+	Longitude float64 `json:"longitude"`
+	Latitude  float64 `json:"latitude"`
+}
+
+// matrix is a 3x3 matrix, used for rotating vectors between frames of
+// reference
+type matrix [3][3]float64
+
+// vector is a vector in three dimensions
+type vector [3]float64
+
+// JulianCalendarDate is a date in the (proleptic) Julian calendar. Years are
+// numbered astronomically, so that 1 BC is year 0.
+type JulianCalendarDate struct {
+	Year  int        `json:"year"`
+	Month time.Month `json:"month"`
+	Day   int        `json:"day"`
+}
+
+// Reformation is the switch from the Julian to the Gregorian calendar in a
+// particular region
+type Reformation struct {
+	// first is the julianTime at the start of the first day of the
+	// Gregorian calendar
+	first julianTime
+}
+
+// TimeScale is a standard by which the passage of time is measured
+type TimeScale int
+
+const (
+	// UTC is Coordinated Universal Time, the basis of civil time, which is
+	// kept close to UT1 by the insertion of leap seconds
+	UTC TimeScale = iota
+
+	// TAI is International Atomic Time
+	TAI
+
+	// TT is Terrestrial Time, the uniform time scale used for ephemerides,
+	// which runs 32.184 seconds ahead of TAI
+	TT
+
+	// UT1 is the time scale defined by the rotation of the Earth
+	UT1
+)